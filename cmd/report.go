@@ -1,9 +1,13 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
 	"os"
+	"os/signal"
 	"path/filepath"
+	"syscall"
+	"time"
 
 	"github.com/s3pweb/gitArchiveS3Report/config"
 	"github.com/s3pweb/gitArchiveS3Report/processrepos/excel"
@@ -11,7 +15,11 @@ import (
 )
 
 var (
-	devSheets bool
+	devSheets   bool
+	reportSince string
+	reportUntil string
+	reportAsOf  string
+	repoTimeout time.Duration
 )
 
 var reportCmd = &cobra.Command{
@@ -21,17 +29,69 @@ var reportCmd = &cobra.Command{
 			- Branches
 			- Main branches
 			- Develop branches
-			- Files and terms to search in each branch`,
+			- Files and terms to search in each branch
+
+The commit statistics can be restricted to a window with --since and --until (YYYY-MM-DD, inclusive).
+With --as-of, each branch is analyzed at its last commit on or before that date.
+
+A repository whose analysis takes longer than --timeout is left out of the report. On Ctrl+C,
+the repositories being analyzed are stopped and a partial report is written with those already
+analyzed; a second Ctrl+C quits immediately.`,
 	Args: cobra.MinimumNArgs(0),
 	Run: func(cmd *cobra.Command, args []string) {
 		cfg := config.Get()
 		cfg.App.DevSheets = devSheets
 
+		if reportSince != "" {
+			since, err := config.ParseDate(reportSince)
+			if err != nil {
+				fmt.Printf("Error reading --since: %v\n", err)
+				os.Exit(1)
+			}
+			cfg.App.ReportSince = since
+		}
+		if reportUntil != "" {
+			until, err := config.ParseDate(reportUntil)
+			if err != nil {
+				fmt.Printf("Error reading --until: %v\n", err)
+				os.Exit(1)
+			}
+			cfg.App.ReportUntil = until
+		}
+		if reportAsOf != "" {
+			asOf, err := config.ParseDate(reportAsOf)
+			if err != nil {
+				fmt.Printf("Error reading --as-of: %v\n", err)
+				os.Exit(1)
+			}
+			cfg.App.ReportAsOf = asOf
+		}
+		if !cfg.App.ReportSince.IsZero() && !cfg.App.ReportUntil.IsZero() && cfg.App.ReportUntil.Before(cfg.App.ReportSince) {
+			fmt.Printf("Error: the end of the report window is before its start\n")
+			os.Exit(1)
+		}
+
+		if cmd.Flags().Changed("timeout") {
+			if repoTimeout < 0 {
+				fmt.Printf("Error: --timeout cannot be negative\n")
+				os.Exit(1)
+			}
+			cfg.App.RepoTimeout = repoTimeout
+		}
+
 		if dirpath == "" {
 			dirpath = filepath.Join(cfg.App.DefaultCloneDir, cfg.Bitbucket.Workspace)
 		}
 
-		err := excel.ReportExcel(dirpath, cfg.App.DefaultCloneDir, devSheets)
+		// The first interrupt cancels the collection, the next ones stop the program as usual
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+		defer stop()
+		go func() {
+			<-ctx.Done()
+			stop()
+		}()
+
+		err := excel.ReportExcel(ctx, dirpath, cfg.App.DefaultCloneDir, devSheets)
 		if err != nil {
 			fmt.Printf("Error generating Excel report: %v\n", err)
 			os.Exit(1)
@@ -42,5 +102,9 @@ var reportCmd = &cobra.Command{
 func init() {
 	reportCmd.Flags().StringVarP(&dirpath, "dir-path", "p", "", "Folder path (default: DIR/BITBUCKET_WORKSPACE in .env)")
 	reportCmd.Flags().BoolVarP(&devSheets, "dev-sheets", "d", false, "Include developer sheets in the report (default: false)")
+	reportCmd.Flags().StringVar(&reportSince, "since", "", "Only count the commits made on or after this date, YYYY-MM-DD (default: REPORT_SINCE in .env)")
+	reportCmd.Flags().StringVar(&reportUntil, "until", "", "Only count the commits made on or before this date, YYYY-MM-DD (default: REPORT_UNTIL in .env)")
+	reportCmd.Flags().StringVar(&reportAsOf, "as-of", "", "Analyze each branch as it was at the end of this date, YYYY-MM-DD, without checking it out (optional)")
+	reportCmd.Flags().DurationVar(&repoTimeout, "timeout", 0, "Time limit of the analysis of one repository, e.g. 10m, 0 for none (default: REPO_TIMEOUT in .env)")
 	rootCmd.AddCommand(reportCmd)
 }
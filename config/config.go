@@ -1,11 +1,17 @@
 package config
 
 import (
+	"fmt"
 	"os"
+	"strconv"
 	"strings"
+	"time"
 
+	"github.com/ProtonMail/go-crypto/openpgp"
 	"github.com/s3pweb/gitArchiveS3Report/utils"
+	gitUtils "github.com/s3pweb/gitArchiveS3Report/utils/git"
 	"github.com/s3pweb/gitArchiveS3Report/utils/logger"
+	"github.com/s3pweb/gitArchiveS3Report/utils/rules"
 	"github.com/spf13/viper"
 )
 
@@ -40,30 +46,54 @@ type LoggerConfig struct {
 }
 
 type AppConfig struct {
-	CPU                  int
-	DevelopersMap        string
-	DefaultColumns       []string
-	TermsToSearch        []string
-	FilesToSearch        []string
-	ForbiddenFiles       []string
-	TermsFilesToCount    []string
-	DefaultCloneDir      string
-	DestDir              string
-	MainBranchOnly       bool
-	ShallowClone         bool
-	DevSheets            bool
-	CountThresholdLow    int
-	CountThresholdMedium int
-	JiraBaseURL          string
-	JiraTaskEnabled      bool
-	JiraParentTask       string
-	JiraTitleTemplate    string
-	JiraDescTemplate     string
-	JiraDocLinks         []string
-	JiraProjectKey       string
-	JiraIssueType        string
-	JiraUsername         string
-	JiraAPIToken         string
+	CPU                   int
+	DevelopersMap         string
+	DefaultColumns        []string
+	TermsToSearch         []string
+	FilesToSearch         []string
+	ForbiddenFiles        []string
+	TermsFilesToCount     []string
+	RulesFile             string
+	Rules                 []rules.Rule
+	RulesExclude          []string
+	ApprovedLicenses      []string
+	CompliancePenalties   map[string]float64
+	DefaultCloneDir       string
+	DestDir               string
+	MainBranchOnly        bool
+	BranchRoles           []gitUtils.BranchRole
+	ShallowClone          bool
+	DevSheets             bool
+	CountThresholdLow     int
+	CountThresholdMedium  int
+	BranchStaleDays       int
+	BranchAbandonedDays   int
+	ReleasePendingCommits int
+	ActivityMonths        int
+	ActivityTopN          int
+	BusFactorThreshold    float64
+	BusFactorBasis        string
+	FormerEmployees       []string
+	ChurnDays             int
+	ReportSince           time.Time
+	ReportUntil           time.Time
+	ReportAsOf            time.Time
+	RepoTimeout           time.Duration
+	SigningKeyringFile    string
+	SigningKeyring        openpgp.EntityList
+	LFSThresholdMB        float64
+	ReadmeSections        []string
+	ReadmeMinWords        int
+	JiraBaseURL           string
+	JiraTaskEnabled       bool
+	JiraParentTask        string
+	JiraTitleTemplate     string
+	JiraDescTemplate      string
+	JiraDocLinks          []string
+	JiraProjectKey        string
+	JiraIssueType         string
+	JiraUsername          string
+	JiraAPIToken          string
 }
 
 // Init initializes the configuration
@@ -100,6 +130,19 @@ func Init() {
 	viper.SetDefault("app.cloneDir", "./repositories")
 	viper.SetDefault("app.countThresholdLow", 30)
 	viper.SetDefault("app.countThresholdMedium", 60)
+	viper.SetDefault("BRANCH_STALE_DAYS", 30)
+	viper.SetDefault("BRANCH_ABANDONED_DAYS", 90)
+	viper.SetDefault("RELEASE_PENDING_COMMITS", 20)
+	viper.SetDefault("ACTIVITY_MONTHS", 12)
+	viper.SetDefault("ACTIVITY_TOP_N", 5)
+	viper.SetDefault("BUS_FACTOR_THRESHOLD", 50)
+	viper.SetDefault("BUS_FACTOR_BASIS", "commits")
+	viper.SetDefault("CHURN_DAYS", 90)
+	viper.SetDefault("LFS_THRESHOLD_MB", 10)
+	viper.SetDefault("README_MIN_WORDS", 100)
+	viper.SetDefault("REPO_TIMEOUT", "30m")
+	viper.SetDefault("BRANCH_ROLES", "develop=develop")
+	viper.SetDefault("RULES_EXCLUDE", "**/testdata/**;**/fixtures/**;**/__fixtures__/**")
 
 	// Load config into struct
 	cfg = &Config{}
@@ -122,6 +165,41 @@ func Init() {
 	// Count thresholds
 	cfg.App.CountThresholdLow = viper.GetInt("COUNT_THRESHOLD_LOW")
 	cfg.App.CountThresholdMedium = viper.GetInt("COUNT_THRESHOLD_MEDIUM")
+	cfg.App.BranchStaleDays = viper.GetInt("BRANCH_STALE_DAYS")
+	cfg.App.BranchAbandonedDays = viper.GetInt("BRANCH_ABANDONED_DAYS")
+	cfg.App.ReleasePendingCommits = viper.GetInt("RELEASE_PENDING_COMMITS")
+	cfg.App.ActivityMonths = viper.GetInt("ACTIVITY_MONTHS")
+	cfg.App.ActivityTopN = viper.GetInt("ACTIVITY_TOP_N")
+	cfg.App.BusFactorThreshold = viper.GetFloat64("BUS_FACTOR_THRESHOLD")
+	cfg.App.BusFactorBasis = viper.GetString("BUS_FACTOR_BASIS")
+	cfg.App.ChurnDays = viper.GetInt("CHURN_DAYS")
+	cfg.App.LFSThresholdMB = viper.GetFloat64("LFS_THRESHOLD_MB")
+	cfg.App.ReadmeSections = utils.FilterEmpty(strings.Split(viper.GetString("README_REQUIRED_SECTIONS"), ";"))
+	cfg.App.ReadmeMinWords = viper.GetInt("README_MIN_WORDS")
+	cfg.App.FormerEmployees = strings.Split(viper.GetString("FORMER_EMPLOYEES"), ";")
+
+	// Report window: the history statistics only count the commits between these dates
+	var err error
+	if cfg.App.ReportSince, err = ParseDate(viper.GetString("REPORT_SINCE")); err != nil {
+		log.Error("Error reading REPORT_SINCE: %v", err)
+		os.Exit(1)
+	}
+	if cfg.App.ReportUntil, err = ParseDate(viper.GetString("REPORT_UNTIL")); err != nil {
+		log.Error("Error reading REPORT_UNTIL: %v", err)
+		os.Exit(1)
+	}
+
+	// Roles of the branches other than the default branch, which decide the sheets they are in
+	if cfg.App.BranchRoles, err = gitUtils.ParseBranchRoles(viper.GetString("BRANCH_ROLES")); err != nil {
+		log.Error("Error reading BRANCH_ROLES: %v", err)
+		os.Exit(1)
+	}
+
+	// Time limit of the analysis of one repository, 0 for none
+	if cfg.App.RepoTimeout, err = time.ParseDuration(viper.GetString("REPO_TIMEOUT")); err != nil || cfg.App.RepoTimeout < 0 {
+		log.Error("Error reading REPO_TIMEOUT: %v", viper.GetString("REPO_TIMEOUT"))
+		os.Exit(1)
+	}
 
 	// Bitbucket Configuration
 	cfg.Bitbucket.Token = viper.GetString("BITBUCKET_TOKEN")
@@ -161,6 +239,91 @@ func Init() {
 	cfg.App.FilesToSearch = utils.FilterEmpty(cfg.App.FilesToSearch)
 	cfg.App.TermsFilesToCount = utils.FilterEmpty(cfg.App.TermsFilesToCount)
 	cfg.App.ForbiddenFiles = utils.FilterEmpty(cfg.App.ForbiddenFiles)
+	cfg.App.FormerEmployees = utils.FilterEmpty(cfg.App.FormerEmployees)
+
+	// Compliance rules: the flat search lists are converted into rules, followed by the rules
+	// of the optional rules file and the approved licenses rule
+	cfg.App.Rules = rules.FromLegacy(cfg.App.FilesToSearch, cfg.App.TermsToSearch, cfg.App.ForbiddenFiles)
+	cfg.App.RulesExclude = utils.FilterEmpty(strings.Split(viper.GetString("RULES_EXCLUDE"), ";"))
+	cfg.App.RulesFile = viper.GetString("RULES_FILE")
+	if cfg.App.RulesFile != "" {
+		fileRules, err := rules.Load(cfg.App.RulesFile, cfg.App.Rules)
+		if err != nil {
+			log.Error("Error loading rules file: %v", err)
+			os.Exit(1)
+		}
+		cfg.App.Rules = append(cfg.App.Rules, fileRules...)
+	}
+	cfg.App.ApprovedLicenses = utils.FilterEmpty(strings.Split(viper.GetString("APPROVED_LICENSES"), ";"))
+	if len(cfg.App.ApprovedLicenses) > 0 {
+		cfg.App.Rules = append(cfg.App.Rules, rules.FromApprovedLicenses(cfg.App.ApprovedLicenses))
+	}
+
+	// Commit signatures are verified against the optional keyring of trusted public keys
+	cfg.App.SigningKeyringFile = viper.GetString("SIGNING_KEYRING")
+	if cfg.App.SigningKeyringFile != "" {
+		cfg.App.SigningKeyring, err = gitUtils.LoadKeyring(cfg.App.SigningKeyringFile)
+		if err != nil {
+			log.Error("Error loading signing keyring: %v", err)
+			os.Exit(1)
+		}
+	}
+
+	// Compliance score: weights of the searched files and terms, and penalties of the forbidden files by severity
+	weights, err := parseWeights(viper.GetString("COMPLIANCE_WEIGHTS"))
+	if err != nil {
+		log.Error("Error reading COMPLIANCE_WEIGHTS: %v", err)
+		os.Exit(1)
+	}
+	for i := range cfg.App.Rules {
+		if weight, ok := weights[cfg.App.Rules[i].ID]; ok && cfg.App.Rules[i].Group != "" {
+			cfg.App.Rules[i].Weight = weight
+		}
+	}
+
+	penalties, err := parseWeights(viper.GetString("COMPLIANCE_PENALTIES"))
+	if err != nil {
+		log.Error("Error reading COMPLIANCE_PENALTIES: %v", err)
+		os.Exit(1)
+	}
+	cfg.App.CompliancePenalties = make(map[string]float64)
+	for severity, penalty := range rules.DefaultPenalties {
+		cfg.App.CompliancePenalties[severity] = penalty
+	}
+	for severity, penalty := range penalties {
+		cfg.App.CompliancePenalties[severity] = penalty
+	}
+}
+
+// parseWeights parses a list of "key=number" separated by semicolons.
+// The key is everything before the last "=" so that it can be a regex.
+func parseWeights(value string) (map[string]float64, error) {
+	weights := make(map[string]float64)
+	for _, item := range utils.FilterEmpty(strings.Split(value, ";")) {
+		index := strings.LastIndex(item, "=")
+		if index <= 0 {
+			return nil, fmt.Errorf("invalid entry %q, expected key=number", item)
+		}
+		number, err := strconv.ParseFloat(strings.TrimSpace(item[index+1:]), 64)
+		if err != nil || number < 0 {
+			return nil, fmt.Errorf("invalid number in %q", item)
+		}
+		weights[strings.TrimSpace(item[:index])] = number
+	}
+	return weights, nil
+}
+
+// ParseDate parses a YYYY-MM-DD date in the local time zone. An empty value gives the zero time.
+func ParseDate(value string) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return time.Time{}, nil
+	}
+	date, err := time.ParseInLocation("2006-01-02", value, time.Local)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid date %q, expected YYYY-MM-DD", value)
+	}
+	return date, nil
 }
 
 // Get returns the configuration instance
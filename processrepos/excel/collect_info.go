@@ -1,12 +1,11 @@
 package excel
 
 import (
-	"bufio"
+	"context"
+	"errors"
 	"fmt"
-	"math"
 	"os"
 	"path/filepath"
-	"regexp"
 	"sort"
 	"strings"
 	"sync"
@@ -14,10 +13,18 @@ import (
 
 	"github.com/go-git/go-git/v5"
 	"github.com/go-git/go-git/v5/plumbing"
-	"github.com/go-git/go-git/v5/plumbing/object"
 	"github.com/s3pweb/gitArchiveS3Report/config"
+	"github.com/s3pweb/gitArchiveS3Report/utils/bitbucket"
+	"github.com/s3pweb/gitArchiveS3Report/utils/codeowners"
+	"github.com/s3pweb/gitArchiveS3Report/utils/compose"
+	"github.com/s3pweb/gitArchiveS3Report/utils/dockerfile"
+	"github.com/s3pweb/gitArchiveS3Report/utils/docs"
 	gitUtils "github.com/s3pweb/gitArchiveS3Report/utils/git"
+	"github.com/s3pweb/gitArchiveS3Report/utils/languages"
+	"github.com/s3pweb/gitArchiveS3Report/utils/licenses"
 	"github.com/s3pweb/gitArchiveS3Report/utils/logger"
+	"github.com/s3pweb/gitArchiveS3Report/utils/pipelines"
+	"github.com/s3pweb/gitArchiveS3Report/utils/rules"
 	"github.com/s3pweb/gitArchiveS3Report/utils/structs"
 
 	"github.com/alitto/pond"
@@ -26,6 +33,7 @@ import (
 // CollectBranchInfoForOneRepo collects information about branches in a given Git repository.
 //
 // Parameters:
+//   - ctx: A context that stops the collection between branches and during the history walks.
 //   - logger: A pointer to a logger.Logger instance for logging messages.
 //   - branchesInfo: A slice of structs.BranchInfo to store information about branches.
 //   - path: The file path to the Git repository.
@@ -40,8 +48,10 @@ import (
 //  3. Retrieves the list of branches in the repository.
 //  4. Reads configuration and name replacement information from a ".config" file.
 //  5. Iterates over each branch and checks out the branch.
-//  6. Collects information about the last commit, the number of commits, and the top developer for each branch.
-//  7. Searches for specified files and terms in the repository.
+//  6. Walks the history of each branch once to collect the last commit, the number of commits,
+//     the top developer and the monthly commit counts. Only the commits of the report window
+//     (REPORT_SINCE and REPORT_UNTIL, or the --since and --until flags) are counted.
+//  7. Evaluates the compliance rules, including the specified files and terms, against the repository.
 //  8. Appends the collected information to the branchesInfo slice.
 //
 // The collected information includes:
@@ -50,14 +60,31 @@ import (
 //   - Last commit date
 //   - Time since the last commit
 //   - Number of commits
-//   - Host line from the Docker Compose file
+//   - Services, images, ports, networks and Traefik hosts from the docker-compose files
+//   - Base images and lint findings of the Dockerfiles
+//   - Pipelines, images, deployments, pipes and quality steps of the Bitbucket Pipelines configuration
 //   - Last developer and their contribution percentage
 //   - Top developer and their contribution percentage
 //   - Presence of specified files and terms
+//   - Result of each compliance rule of the rules file
+//   - Weighted compliance score and grade
+//   - Merge status and ahead/behind counts against the default branch, and staleness status
+//   - Monthly commit counts over the activity window and the activity trend
+//   - Lines added and removed over the churn window, per branch and per author
+//   - Share of the commit messages following Conventional Commits and referencing an issue key
+//   - Shares of the signed, verified and unsigned commits, and whether the last commit is signed
+//   - Size on disk, object count and largest files of the repository, current and historical
+//   - Bus factor and share of the former employees, computed on the default branch
+//   - Latest tag, days and commits since the last release, and divergence of develop from the default branch
 //   - Count of found items
+//   - License of the repository and the confidence of its detection
+//   - CODEOWNERS file, its owners and the share of the tracked files it covers
+//   - README, its word count and missing sections, documentation folder, changelog and documentation score
+//   - Line counts per language and the dominant language
+//   - Test files, test code lines and the ratio of test code to the other code
 //   - Whether the repository is a shallow clone
 //   - Clone depth
-func CollectBranchInfoForOneRepo(logger *logger.Logger, branchesInfo []structs.BranchInfo, path string) ([]structs.BranchInfo, error) {
+func CollectBranchInfoForOneRepo(ctx context.Context, logger *logger.Logger, branchesInfo []structs.BranchInfo, path string) ([]structs.BranchInfo, error) {
 	var infos []structs.BranchInfo
 
 	isShallow := gitUtils.IsShallowClone(path)
@@ -65,24 +92,64 @@ func CollectBranchInfoForOneRepo(logger *logger.Logger, branchesInfo []structs.B
 
 	repo, err := git.PlainOpen(path)
 	if err != nil {
-		return nil, err
+		return nil, &stageError{stage: structs.ProblemStageOpen, err: err}
 	}
 
 	branches, err := gitUtils.Branches(repo)
 	if err != nil {
-		return nil, err
+		return nil, &stageError{stage: structs.ProblemStageOpen, err: err}
 	}
 
 	worktree, err := repo.Worktree()
 	if err != nil {
-		return nil, err
+		return nil, &stageError{stage: structs.ProblemStageOpen, err: err}
 	}
 
 	logger.Trace("Branches: %v", branches)
 
+	cfg := config.Get()
+
+	// In a point-in-time report, each branch is read at its last commit before the end of the
+	// as-of day, from a copy of its tree: the working copy is not checked out. The durations
+	// and statuses are then computed relative to that day.
+	now := time.Now()
+	var asOfBefore time.Time
+	var asOfDir string
+	if !cfg.App.ReportAsOf.IsZero() {
+		asOfBefore = cfg.App.ReportAsOf.AddDate(0, 0, 1)
+		now = asOfBefore
+		asOfDir, err = os.MkdirTemp("", "gitarchive-as-of-*")
+		if err != nil {
+			return nil, err
+		}
+		defer os.RemoveAll(asOfDir)
+	}
+
+	// The default branch is resolved before the checkouts move HEAD, from origin/HEAD or else from
+	// the Bitbucket API. Merge status and ahead/behind counts need the full history, so they are
+	// skipped for shallow clones.
+	var remoteDefault string
+	if gitUtils.OriginHead(repo) == "" && bitbucket.Enabled() {
+		remoteDefault, err = bitbucket.MainBranch(ctx, filepath.Base(path))
+		if err != nil {
+			logger.Warn("Failed to read the main branch of repository: %s from Bitbucket [%s]", path, err)
+		}
+	}
+	defaultBranch := gitUtils.DefaultBranch(repo, branches, remoteDefault)
+	var defaultAncestors map[plumbing.Hash]bool
+	if defaultBranch != "" && !isShallow {
+		defaultHash, err := gitUtils.BranchHashBefore(repo, defaultBranch, asOfBefore)
+		if err == nil {
+			defaultAncestors, err = gitUtils.Ancestors(repo, defaultHash)
+		}
+		if err != nil {
+			logger.Warn("Failed to read default branch: %s in repository: %s [%s]", defaultBranch, path, err)
+			defaultAncestors = nil
+		}
+	}
+
 	localBranches := make(map[string]bool)
 
-	cfg := config.Get()
 	replacements := make(map[string]string)
 	if cfg.App.DevelopersMap != "" {
 		for _, mapping := range strings.Split(cfg.App.DevelopersMap, ";") {
@@ -93,7 +160,42 @@ func CollectBranchInfoForOneRepo(logger *logger.Logger, branchesInfo []structs.B
 		}
 	}
 
+	release := collectReleaseInfo(logger, repo, path, branches, defaultBranch, defaultAncestors, cfg.App.BranchRoles, asOfBefore)
+
+	// The size and the largest files are measured once per repository, on the default branch
+	var sizeTip plumbing.Hash
+	if defaultBranch != "" {
+		sizeTip, _ = gitUtils.BranchHashBefore(repo, defaultBranch, asOfBefore)
+	} else if head, err := repo.Head(); err == nil && asOfBefore.IsZero() {
+		sizeTip = head.Hash()
+	}
+	size := collectSizeInfo(ctx, logger, repo, path, sizeTip, asOfBefore, cfg.App.LFSThresholdMB)
+
+	// Commit counts, developer percentages and activity only cover the report window,
+	// which ends with the as-of day in a point-in-time report
+	window := historyWindow{since: cfg.App.ReportSince, until: cfg.App.ReportUntil}
+	if !cfg.App.ReportAsOf.IsZero() && (window.until.IsZero() || window.until.After(cfg.App.ReportAsOf)) {
+		window.until = cfg.App.ReportAsOf
+	}
+	historyOptions := historyOptions{
+		ctx:         ctx,
+		excludeUser: "bitbucket-pipelines",
+		window:      window,
+		months:      cfg.App.ActivityMonths,
+		churnDays:   cfg.App.ChurnDays,
+		issueKey:    issueKeyRegex(cfg.App.JiraProjectKey),
+		keyring:     cfg.App.SigningKeyring,
+		verified:    make(map[plumbing.Hash]bool),
+	}
+
+	// The knowledge metrics are computed on the default branch, or the first branch without one
+	var knowledge structs.KnowledgeInfo
+	knowledgeCollected := false
+
 	for _, branchName := range branches {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
 
 		if !strings.HasPrefix(branchName, "origin/") {
 			localBranches[branchName] = true
@@ -106,19 +208,48 @@ func CollectBranchInfoForOneRepo(logger *logger.Logger, branchesInfo []structs.B
 			}
 		}
 
-		if strings.HasPrefix(branchName, "origin/") {
-			err = worktree.Checkout(&git.CheckoutOptions{
-				Branch: plumbing.NewRemoteReferenceName("origin", strings.TrimPrefix(branchName, "origin/")),
-			})
+		// tip is the commit the branch is analyzed at, and treePath the directory holding its files
+		var tip plumbing.Hash
+		treePath := path
+		if asOfDir != "" {
+			tip, err = gitUtils.BranchHashBefore(repo, branchName, asOfBefore)
+			if errors.Is(err, gitUtils.ErrNoCommitBefore) {
+				logger.Debug("Branch: %s in repository: %s has no commit as of %s", branchName, path, cfg.App.ReportAsOf.Format("2006-01-02"))
+				continue
+			}
+			if err != nil {
+				logger.Error("Failed to read branch: %s in repository: %s [%s]", branchName, path, err)
+				return nil, err
+			}
+			treePath = filepath.Join(asOfDir, "tree")
+			if err := os.RemoveAll(treePath); err != nil {
+				return nil, err
+			}
+			if err := gitUtils.WriteTree(repo, tip, treePath); err != nil {
+				logger.Error("Failed to read the files of branch: %s in repository: %s [%s]", branchName, path, err)
+				return nil, &stageError{stage: structs.ProblemStageCheckout, err: fmt.Errorf("branch %s: %v", branchName, err)}
+			}
 		} else {
-			err = worktree.Checkout(&git.CheckoutOptions{
-				Branch: plumbing.NewBranchReferenceName(branchName),
-			})
-		}
+			if strings.HasPrefix(branchName, "origin/") {
+				err = worktree.Checkout(&git.CheckoutOptions{
+					Branch: plumbing.NewRemoteReferenceName("origin", strings.TrimPrefix(branchName, "origin/")),
+				})
+			} else {
+				err = worktree.Checkout(&git.CheckoutOptions{
+					Branch: plumbing.NewBranchReferenceName(branchName),
+				})
+			}
 
-		if err != nil {
-			logger.Error("Failed to checkout branch: %s in repository: %s [%s]", branchName, path, err)
-			return nil, err
+			if err != nil {
+				logger.Error("Failed to checkout branch: %s in repository: %s [%s]", branchName, path, err)
+				return nil, &stageError{stage: structs.ProblemStageCheckout, err: fmt.Errorf("branch %s: %v", branchName, err)}
+			}
+
+			head, err := repo.Head()
+			if err != nil {
+				return nil, err
+			}
+			tip = head.Hash()
 		}
 
 		var lastDeveloper string
@@ -128,12 +259,22 @@ func CollectBranchInfoForOneRepo(logger *logger.Logger, branchesInfo []structs.B
 		var topDeveloper string
 		var topDeveloperPercentage float64
 
-		if isShallow {
-			head, err := repo.Head()
+		history, err := walkHistory(repo, tip, historyOptions)
+		if err != nil {
+			return nil, err
+		}
+
+		if branchName == defaultBranch || (defaultBranch == "" && !knowledgeCollected) {
+			knowledge, err = collectKnowledge(ctx, repo, tip, history, cfg.App.BusFactorBasis, cfg.App.BusFactorThreshold,
+				cfg.App.FormerEmployees, "bitbucket-pipelines", replacements)
 			if err != nil {
-				return nil, err
+				logger.Warn("Failed to compute the bus factor for branch: %s in repository: %s [%s]", branchName, path, err)
 			}
-			commit, err := repo.CommitObject(head.Hash())
+			knowledgeCollected = true
+		}
+
+		if isShallow {
+			commit, err := repo.CommitObject(tip)
 			if err != nil {
 				return nil, err
 			}
@@ -148,38 +289,126 @@ func CollectBranchInfoForOneRepo(logger *logger.Logger, branchesInfo []structs.B
 			topDeveloper = lastDeveloper
 			topDeveloperPercentage = 100
 		} else {
-			lastDeveloper, lastCommitDate, err = getLastDeveloperExcludingUser(repo, "bitbucket-pipelines", replacements)
-			if err != nil {
-				return nil, err
+			lastDeveloper, lastCommitDate = history.lastDeveloper, history.lastCommitDate
+			commitNbr = history.commitCount
+			lastDeveloperPercentage = history.percentage(lastDeveloper)
+			topDeveloper = history.topDeveloper()
+			topDeveloperPercentage = history.percentage(topDeveloper)
+			if replacement, ok := replacements[lastDeveloper]; ok {
+				lastDeveloper = replacement
 			}
-			commitNbr, err = countCommits(repo, "bitbucket-pipelines")
-			if err != nil {
-				return nil, err
+			if replacement, ok := replacements[topDeveloper]; ok {
+				topDeveloper = replacement
 			}
-			topDeveloper, topDeveloperPercentage, err = getTopDeveloper(repo, "bitbucket-pipelines", replacements)
+		}
+
+		// Churn per author, with the aliases of DEVELOPERS_MAP merged
+		churnByAuthor := make(map[string]structs.Churn)
+		var branchChurn structs.Churn
+		for author, churn := range history.churn {
+			name := replaceName(author, replacements)
+			merged := churnByAuthor[name]
+			merged.Commits += churn.Commits
+			merged.Added += churn.Added
+			merged.Removed += churn.Removed
+			churnByAuthor[name] = merged
+
+			branchChurn.Added += churn.Added
+			branchChurn.Removed += churn.Removed
+		}
+
+		// Commit message conventions per author, with the aliases merged, and for the branch
+		messagesByAuthor := make(map[string]structs.MessageStats)
+		var branchMessages structs.MessageStats
+		for author, messages := range history.messages {
+			name := replaceName(author, replacements)
+			messagesByAuthor[name] = mergeMessages(messagesByAuthor[name], messages)
+			branchMessages = mergeMessages(branchMessages, messages)
+		}
+
+		// Signed and verified commits per author, with the aliases merged, and for the branch
+		signaturesByAuthor := make(map[string]structs.SignatureStats)
+		var branchSignatures structs.SignatureStats
+		for author, signatures := range history.signatures {
+			name := replaceName(author, replacements)
+			signaturesByAuthor[name] = mergeSignatures(signaturesByAuthor[name], signatures)
+			branchSignatures = mergeSignatures(branchSignatures, signatures)
+		}
+		var tipSigned, tipVerified bool
+		if tipCommit, err := repo.CommitObject(tip); err == nil {
+			tipSigned = gitUtils.SignatureKind(tipCommit) != ""
+			tipVerified = historyOptions.verify(tipCommit)
+		}
+
+		var isMerged bool
+		var ahead, behind int
+		if defaultAncestors != nil && branchName != defaultBranch {
+			branchAncestors, err := gitUtils.Ancestors(repo, tip)
 			if err != nil {
 				return nil, err
 			}
-			lastDeveloperPercentage = calculateDeveloperPercentage(repo, lastDeveloper)
+			ahead, behind = gitUtils.AheadBehind(branchAncestors, defaultAncestors)
+			isMerged = ahead == 0
 		}
 
-		dockerComposeName := getDockerComposeFileName(path)
-		hostLine := getHostLine(path, dockerComposeName)
-		timeSinceLastCommit := formatDuration(time.Since(lastCommitDate))
+		composeFiles, services, err := compose.Analyze(treePath)
+		if err != nil {
+			logger.Warn("Failed to parse docker-compose files for branch: %s in repository: %s [%s]", branchName, path, err)
+		}
 
-		filesToSearchMap := make(map[string]bool)
-		for _, file := range cfg.App.FilesToSearch {
-			filesToSearchMap[file] = fileExistsIgnoreCase(path, file)
+		dockerfiles, baseImages, dockerFindings, err := dockerfile.Analyze(treePath)
+		if err != nil {
+			logger.Warn("Failed to parse Dockerfiles for branch: %s in repository: %s [%s]", branchName, path, err)
 		}
 
-		termsToSearchMap := make(map[string]bool)
-		for _, term := range cfg.App.TermsToSearch {
-			termsToSearchMap[term] = searchInFiles(path, term)
+		ci := pipelines.Analyze(treePath)
+		if ci.ParseError != "" {
+			logger.Warn("Failed to parse %s for branch: %s in repository: %s [%s]", pipelines.FileName, branchName, path, ci.ParseError)
+		}
+		timeSinceLastCommit := formatDuration(now.Sub(lastCommitDate))
+
+		// The tracked files are the files of the commit, not the ignored ones of the working copy:
+		// ownership is computed on them, and the rules keep the committed files that .gitignore matches
+		var trackedFiles []string
+		if blobs, err := gitUtils.TreeFiles(ctx, repo, tip); err == nil {
+			for _, blob := range blobs {
+				trackedFiles = append(trackedFiles, blob.Path)
+			}
+		} else {
+			logger.Warn("Failed to list the files of branch: %s in repository: %s [%s]", branchName, path, err)
+		}
+
+		license, err := licenses.Detect(treePath)
+		if err != nil {
+			logger.Warn("Failed to detect the license for branch: %s in repository: %s [%s]", branchName, path, err)
 		}
 
+		ruleResults, err := rules.Evaluate(treePath, trackedFiles, &license, cfg.App.Rules, cfg.App.RulesExclude)
+		if err != nil {
+			logger.Warn("Failed to evaluate compliance rules for branch: %s in repository: %s [%s]", branchName, path, err)
+		}
+
+		// The rules built from the flat search lists fill the historical maps,
+		// where a forbidden file is true when it is present
+		filesToSearchMap := make(map[string]bool)
+		termsToSearchMap := make(map[string]bool)
 		forbiddenFilesMap := make(map[string]bool)
-		for _, file := range cfg.App.ForbiddenFiles {
-			forbiddenFilesMap[file] = fileExistsIgnoreCase(path, file)
+		customRulesMap := make(map[string]structs.RuleResult)
+		rulePaths := make(map[string][]string)
+		for i, result := range ruleResults {
+			if len(result.Paths) > 0 {
+				rulePaths[result.ID] = result.Paths
+			}
+			switch cfg.App.Rules[i].Group {
+			case rules.GroupFiles:
+				filesToSearchMap[result.ID] = result.Passed
+			case rules.GroupTerms:
+				termsToSearchMap[result.ID] = result.Passed
+			case rules.GroupForbidden:
+				forbiddenFilesMap[result.ID] = !result.Passed
+			default:
+				customRulesMap[result.ID] = result
+			}
 		}
 
 		trueForbiddenCount := countTrueInMap(forbiddenFilesMap)
@@ -202,17 +431,43 @@ func CollectBranchInfoForOneRepo(logger *logger.Logger, branchesInfo []structs.B
 			}
 		}
 
+		passedRules := 0
+		for _, result := range customRulesMap {
+			if result.Passed {
+				passedRules++
+			}
+		}
+		rulesCount := fmt.Sprintf("%d/%d", passedRules, len(customRulesMap))
+		complianceScore := rules.Score(cfg.App.Rules, ruleResults, cfg.App.CompliancePenalties)
+
 		selectiveTrueCount := countTrueInMap(selectiveCountMap)
 		selectiveTotalCount := len(selectiveCountMap)
 		selectiveCount := fmt.Sprintf("%d/%d", selectiveTrueCount, selectiveTotalCount)
 
+		docsInfo, err := docs.Analyze(treePath, cfg.App.ReadmeSections, cfg.App.ReadmeMinWords)
+		if err != nil {
+			logger.Warn("Failed to check the documentation for branch: %s in repository: %s [%s]", branchName, path, err)
+		}
+
+		ownership := codeowners.Analyze(treePath, trackedFiles)
+		if len(ownership.ParseErrors) > 0 {
+			logger.Warn("Failed to parse %s for branch: %s in repository: %s [%s]", ownership.File, branchName, path, strings.Join(ownership.ParseErrors, "; "))
+		}
+
+		languageStats, err := languages.Analyze(ctx, treePath)
+		if err != nil {
+			logger.Warn("Failed to count lines of code for branch: %s in repository: %s [%s]", branchName, path, err)
+		}
+		mainLanguage, totalLOC := languages.Dominant(languageStats)
+		testFiles, testLOC, testRatio := languages.Tests(languageStats)
+
 		infos = append(infos, structs.BranchInfo{
 			RepoName:                filepath.Base(path),
 			BranchName:              branchName,
 			LastCommitDate:          lastCommitDate,
 			TimeSinceLastCommit:     timeSinceLastCommit,
 			Commitnbr:               commitNbr,
-			HostLine:                hostLine,
+			HostLine:                strings.Join(serviceValues(services, serviceHosts), " "),
 			LastDeveloper:           lastDeveloper,
 			LastDeveloperPercentage: lastDeveloperPercentage,
 			TopDeveloper:            topDeveloper,
@@ -223,26 +478,136 @@ func CollectBranchInfoForOneRepo(logger *logger.Logger, branchesInfo []structs.B
 			SelectiveCount:          selectiveCount,
 			Count:                   count,
 			ForbiddenCount:          forbiddenCount,
+			RulesCount:              rulesCount,
+			RuleResults:             customRulesMap,
+			RulePaths:               rulePaths,
+			DefaultBranch:           defaultBranch,
+			IsDefault:               branchName == defaultBranch,
+			BranchRole:              gitUtils.RoleOf(branchName, defaultBranch, cfg.App.BranchRoles),
+			IsMerged:                isMerged,
+			Ahead:                   ahead,
+			Behind:                  behind,
+			BranchStatus:            branchStatus(now, lastCommitDate, cfg.App.BranchStaleDays, cfg.App.BranchAbandonedDays),
+			LatestTag:               release.LatestTag,
+			LatestTagSemver:         release.LatestTagSemver,
+			TagCount:                release.TagCount,
+			LastReleaseDate:         release.LastReleaseDate,
+			DaysSinceRelease:        release.DaysSinceRelease,
+			CommitsSinceTag:         release.CommitsSinceTag,
+			DevelopAhead:            release.DevelopAhead,
+			DevelopBehind:           release.DevelopBehind,
+			DevelopDiverged:         release.DevelopDiverged,
+			MonthlyCommits:          history.monthly,
+			ActivityTrend:           activityTrend(history.monthly),
+			LinesAdded:              branchChurn.Added,
+			LinesRemoved:            branchChurn.Removed,
+			Churn:                   branchChurn.Added + branchChurn.Removed,
+			ChurnByAuthor:           churnByAuthor,
+			ConventionalShare:       commitShare(branchMessages.Conventional, branchMessages.Commits),
+			IssueKeyShare:           commitShare(branchMessages.IssueKey, branchMessages.Commits),
+			MessagesByAuthor:        messagesByAuthor,
+			SignedShare:             commitShare(branchSignatures.Signed, branchSignatures.Commits),
+			VerifiedShare:           commitShare(branchSignatures.Verified, branchSignatures.Commits),
+			UnsignedShare:           commitShare(branchSignatures.Commits-branchSignatures.Signed, branchSignatures.Commits),
+			TipSigned:               tipSigned,
+			TipVerified:             tipVerified,
+			SignaturesByAuthor:      signaturesByAuthor,
+			RepoSizeMB:              size.DiskSizeMB,
+			PackSizeMB:              size.PackSizeMB,
+			ObjectCount:             size.ObjectCount,
+			FilesOverLFS:            size.FilesOverLFS,
+			LargestFile:             largestFile(size),
+			Size:                    size,
+			License:                 license.ID,
+			LicenseConfidence:       license.Confidence,
+			LicenseFile:             license.File,
+			HasReadme:               docsInfo.HasReadme,
+			ReadmeWords:             docsInfo.Words,
+			MissingSections:         strings.Join(docsInfo.MissingSections, ", "),
+			HasDocsFolder:           docsInfo.HasDocsFolder,
+			HasChangelog:            docsInfo.HasChangelog,
+			DocScore:                docsInfo.Score,
+			Docs:                    docsInfo,
+			HasCodeOwners:           ownership.Present,
+			CodeOwnersValid:         ownership.Valid,
+			CodeOwners:              strings.Join(ownership.Owners, ", "),
+			OwnershipCoverage:       ownership.Coverage,
+			Ownership:               ownership,
+			ComplianceScore:         complianceScore,
+			ComplianceGrade:         rules.Grade(complianceScore),
 			IsShallow:               isShallow,
 			CloneDepth:              cloneDepth,
+			MainLanguage:            mainLanguage,
+			TotalLOC:                totalLOC,
+			HasTests:                testFiles > 0,
+			TestFiles:               testFiles,
+			TestLOC:                 testLOC,
+			TestRatio:               testRatio,
+			Languages:               languageStats,
+			ComposeFiles:            strings.Join(composeFiles, ", "),
+			ServiceCount:            len(services),
+			Images:                  strings.Join(serviceValues(services, serviceImages), ", "),
+			PublishedPorts:          strings.Join(serviceValues(services, servicePublishedPorts), ", "),
+			Networks:                strings.Join(serviceValues(services, serviceNetworks), ", "),
+			TraefikRules:            strings.Join(serviceValues(services, serviceRules), ", "),
+			Services:                services,
+			Dockerfiles:             strings.Join(dockerfiles, ", "),
+			BaseImages:              strings.Join(baseImages, ", "),
+			DockerFindingsCount:     len(dockerFindings),
+			DockerFindings:          dockerFindings,
+			CIPipelines:             strings.Join(ci.Pipelines, ", "),
+			CIImages:                strings.Join(ci.Images, ", "),
+			CIHasTests:              ci.HasTests,
+			CIHasLint:               ci.HasLint,
+			CIHasSonar:              ci.HasSonar,
+			CIDeployments:           strings.Join(ci.Deployments, ", "),
+			CIPipes:                 strings.Join(ci.Pipes, ", "),
+			CIGaps:                  strings.Join(ci.Gaps, ", "),
+			CI:                      ci,
 		})
 	}
+
+	// A repository without any analyzed branch is listed as a problem, so that it is not missing from
+	// both the report and the Problems sheet: a point-in-time report leaves out the repositories
+	// created after its date, and a clone may have no branch to analyze
+	if len(infos) == 0 && asOfDir != "" {
+		return nil, &stageError{stage: structs.ProblemStageFiltered, err: fmt.Errorf("no commit as of %s", cfg.App.ReportAsOf.Format("2006-01-02"))}
+	}
+	if len(infos) == 0 {
+		return nil, &stageError{stage: structs.ProblemStageFiltered, err: fmt.Errorf("no branch to analyze")}
+	}
+
+	for i := range infos {
+		infos[i].Knowledge = knowledge
+		infos[i].BusFactor = knowledge.BusFactor
+		infos[i].BusFactorAuthors = strings.Join(knowledge.KeyAuthors, ", ")
+		infos[i].FormerEmployeesShare = knowledge.FormerShare
+		infos[i].FormerEmployees = strings.Join(knowledge.FormerAuthors, ", ")
+	}
 	return infos, nil
 }
 
 // CollectBranchInfo collects branch information from git repositories located in the specified base path.
 // It uses a thread pool to process multiple repositories concurrently.
 //
+// Each repository is analyzed within REPO_TIMEOUT. When ctx is cancelled, the repositories being analyzed
+// are stopped, the others are not started, and the repositories already analyzed are returned.
+//
 // Parameters:
+//   - ctx: A context whose cancellation stops the collection, e.g. on an interrupt.
 //   - basePath: The base directory path where the git repositories are located.
 //   - logger: A logger instance for logging information, trace, and errors.
 //
 // Returns:
 //   - A slice of BranchInfo structs containing information about the branches in the repositories.
+//   - A slice of RepoProblem structs for the folders of the workspace that are not in the report:
+//     folders that are not Git repositories, empty repositories, repositories that failed to open,
+//     failed checkout, failed to be analyzed or timed out, and those left out by a cancellation,
+//     each with its stage and error message.
 //   - An error if there is an issue reading the directories or processing the repositories.
 //
 // collect_info.go
-func CollectBranchInfo(basePath string, logger *logger.Logger, totalRepos int) ([]structs.BranchInfo, int, error) {
+func CollectBranchInfo(ctx context.Context, basePath string, logger *logger.Logger, totalRepos int) ([]structs.BranchInfo, []structs.RepoProblem, int, error) {
 	startTime := time.Now()
 	logWithTime := func(format string, args ...interface{}) {
 		elapsed := time.Since(startTime).Round(time.Millisecond)
@@ -255,14 +620,20 @@ func CollectBranchInfo(basePath string, logger *logger.Logger, totalRepos int) (
 
 	cfg := config.Get()
 	var branchesInfo []structs.BranchInfo
+	var problems []structs.RepoProblem
 	processedRepos := 0
-	emptyRepos := make([]string, 0)
 
 	nbThreads := cfg.App.CPU
 	if nbThreads <= 0 {
 		nbThreads = 1
 	}
 
+	// Progress is logged every tenth of the repositories, or for each one when there are fewer than ten
+	progressStep := totalRepos / 10
+	if progressStep == 0 {
+		progressStep = 1
+	}
+
 	logWithTime("Using %d threads for processing", nbThreads)
 
 	var mutex sync.Mutex
@@ -270,52 +641,75 @@ func CollectBranchInfo(basePath string, logger *logger.Logger, totalRepos int) (
 
 	folders, err := os.ReadDir(basePath)
 	if err != nil {
-		return nil, 0, err
+		return nil, nil, 0, err
 	}
 
-	// Create buffered channels with precise sizes
-	errorChan := make(chan error, len(folders))
-	emptyRepoChan := make(chan string, len(folders))
+	addProblem := func(repoName, stage string, err error) {
+		mutex.Lock()
+		problems = append(problems, structs.RepoProblem{RepoName: repoName, Stage: stage, Error: err.Error()})
+		mutex.Unlock()
+	}
 
 	for _, oneFolder := range folders {
 		path := filepath.Join(basePath, oneFolder.Name())
 
-		if oneFolder.IsDir() && isGitRepo(path) {
-			pool.Submit(func() {
-				// Check if repository is empty
-				isEmpty, err := isEmptyRepository(path)
-				if err != nil {
-					errorChan <- fmt.Errorf("error checking repository %s: %v", path, err)
-					return
-				}
+		if !oneFolder.IsDir() {
+			continue
+		}
+		if !isGitRepo(path) {
+			addProblem(oneFolder.Name(), structs.ProblemStageFiltered, fmt.Errorf("not a Git repository"))
+			continue
+		}
 
-				if isEmpty {
-					emptyRepoChan <- oneFolder.Name()
-					mutex.Lock()
-					processedRepos++
-					logWithTime("empty repository detected: %s", oneFolder.Name())
-					mutex.Unlock()
-					return
-				}
+		pool.Submit(func() {
+			if ctx.Err() != nil {
+				addProblem(oneFolder.Name(), structs.ProblemStageCancelled, fmt.Errorf("report interrupted before the analysis"))
+				return
+			}
 
-				infos, err := CollectBranchInfoForOneRepo(logger, branchesInfo, path)
+			// Check if repository is empty
+			isEmpty, err := isEmptyRepository(path)
+			if err != nil {
+				logWithTime("Error checking repository %s: %v", path, err)
+				addProblem(oneFolder.Name(), structs.ProblemStageOpen, err)
+				return
+			}
 
+			if isEmpty {
+				addProblem(oneFolder.Name(), structs.ProblemStageEmpty, fmt.Errorf("repository has no commits"))
 				mutex.Lock()
-				if err != nil {
-					logWithTime("Error processing repository %s: %v", path, err)
-					errorChan <- fmt.Errorf("error in repo %s: %v", path, err)
+				processedRepos++
+				logWithTime("empty repository detected: %s", oneFolder.Name())
+				mutex.Unlock()
+				return
+			}
+
+			infos, err := collectRepoWithTimeout(ctx, logger, path, cfg.App.RepoTimeout)
+			if err != nil {
+				stage := structs.ProblemStageAnalysis
+				var staged *stageError
+				if errors.As(err, &staged) {
+					stage = staged.stage
+				}
+				if stage == structs.ProblemStageFiltered || stage == structs.ProblemStageCancelled {
+					logWithTime("Repository %s left out: %v", path, err)
 				} else {
-					branchesInfo = append(branchesInfo, infos...)
-					processedRepos++
-					if processedRepos%(totalRepos/10) == 0 || processedRepos == totalRepos {
-						logWithTime("Progress: %d/%d repositories processed (%.1f%%)",
-							processedRepos, totalRepos,
-							float64(processedRepos)/float64(totalRepos)*100)
-					}
+					logWithTime("Error processing repository %s: %v", path, err)
 				}
-				mutex.Unlock()
-			})
-		}
+				addProblem(oneFolder.Name(), stage, err)
+				return
+			}
+
+			mutex.Lock()
+			branchesInfo = append(branchesInfo, infos...)
+			processedRepos++
+			if processedRepos%progressStep == 0 || processedRepos == totalRepos {
+				logWithTime("Progress: %d/%d repositories processed (%.1f%%)",
+					processedRepos, totalRepos,
+					float64(processedRepos)/float64(totalRepos)*100)
+			}
+			mutex.Unlock()
+		})
 	}
 
 	logWithTime("Waiting for the last repositories to complete processing...")
@@ -329,7 +723,8 @@ func CollectBranchInfo(basePath string, logger *logger.Logger, totalRepos int) (
 		done <- true
 	}()
 
-	// Wait for either completion or ticker
+	// Wait for either completion, ticker or interruption
+	interrupted := ctx.Done()
 waitLoop:
 	for {
 		select {
@@ -338,6 +733,9 @@ waitLoop:
 			break waitLoop
 		case <-ticker.C:
 			logWithTime("Still processing final repositories...")
+		case <-interrupted:
+			logWithTime("Interrupted: stopping the repositories being processed...")
+			interrupted = nil
 		}
 	}
 
@@ -345,23 +743,35 @@ waitLoop:
 
 	logWithTime("Starting post-processing phase...")
 
-	close(emptyRepoChan)
-	for repoName := range emptyRepoChan {
-		emptyRepos = append(emptyRepos, repoName)
+	sort.Slice(problems, func(i, j int) bool {
+		return problems[i].RepoName < problems[j].RepoName
+	})
+
+	var emptyRepos, timedOutRepos, failedRepos []structs.RepoProblem
+	for _, problem := range problems {
+		switch problem.Stage {
+		case structs.ProblemStageEmpty:
+			emptyRepos = append(emptyRepos, problem)
+		case structs.ProblemStageTimeout:
+			timedOutRepos = append(timedOutRepos, problem)
+		case structs.ProblemStageFiltered, structs.ProblemStageCancelled:
+		default:
+			failedRepos = append(failedRepos, problem)
+		}
 	}
 
 	if len(emptyRepos) > 0 {
-		sort.Strings(emptyRepos)
 		logWithTime("Found %d empty repositories:", len(emptyRepos))
-		for _, repoName := range emptyRepos {
-			logger.Warn("Empty repository: %s", repoName)
+		for _, problem := range emptyRepos {
+			logger.Warn("Empty repository: %s", problem.RepoName)
 		}
 	}
 
-	close(errorChan)
-	var errors []error
-	for err := range errorChan {
-		errors = append(errors, err)
+	if len(timedOutRepos) > 0 {
+		logWithTime("%d repositories timed out after %s:", len(timedOutRepos), cfg.App.RepoTimeout)
+		for _, problem := range timedOutRepos {
+			logger.Warn("Timed out repository: %s", problem.RepoName)
+		}
 	}
 
 	sort.Slice(branchesInfo, func(i, j int) bool {
@@ -371,14 +781,50 @@ waitLoop:
 		return branchesInfo[i].RepoName < branchesInfo[j].RepoName
 	})
 
-	if len(errors) > 0 {
-		logWithTime("%d repositories had errors during processing", len(errors))
-		for _, err := range errors {
-			logger.Warn("Repository processing error: %v", err)
+	if len(failedRepos) > 0 {
+		logWithTime("%d repositories had errors during processing", len(failedRepos))
+		for _, problem := range failedRepos {
+			logger.Warn("Repository processing error: %s (%s) %s", problem.RepoName, problem.Stage, problem.Error)
 		}
 	}
 
-	return branchesInfo, processedRepos, nil
+	return branchesInfo, problems, processedRepos, nil
+}
+
+// collectRepoWithTimeout runs CollectBranchInfoForOneRepo within a time limit, 0 for none. The collection
+// stops at its next check of the context and is waited for, so that no repository keeps running, e.g. in
+// the middle of a checkout, once it is reported as timed out or cancelled. A result completed after the
+// context ended may miss some statistics, so it is discarded.
+func collectRepoWithTimeout(ctx context.Context, logger *logger.Logger, path string, timeout time.Duration) ([]structs.BranchInfo, error) {
+	repoCtx, cancel := context.WithCancel(ctx)
+	if timeout > 0 {
+		repoCtx, cancel = context.WithTimeout(ctx, timeout)
+	}
+	defer cancel()
+
+	infos, err := CollectBranchInfoForOneRepo(repoCtx, logger, nil, path)
+	if repoCtx.Err() == nil {
+		return infos, err
+	}
+
+	if errors.Is(repoCtx.Err(), context.DeadlineExceeded) {
+		return nil, &stageError{stage: structs.ProblemStageTimeout, err: fmt.Errorf("timed out after %s", timeout)}
+	}
+	return nil, &stageError{stage: structs.ProblemStageCancelled, err: fmt.Errorf("report interrupted during the analysis")}
+}
+
+// stageError is an error of CollectBranchInfoForOneRepo with the stage at which the repository failed
+type stageError struct {
+	stage string
+	err   error
+}
+
+func (e *stageError) Error() string {
+	return e.err.Error()
+}
+
+func (e *stageError) Unwrap() error {
+	return e.err
 }
 
 // isEmptyRepository checks if a Git repository is empty (no commits)
@@ -400,160 +846,92 @@ func isEmptyRepository(path string) (bool, error) {
 	return false, nil
 }
 
-// getLastDeveloperExcludingUser finds the last developer excluding a specific user and returns the developer's name and the commit date
-func getLastDeveloperExcludingUser(repo *git.Repository, excludeUser string, replacements map[string]string) (string, time.Time, error) {
-	commitIter, err := repo.Log(&git.LogOptions{})
-	if err != nil {
-		return "", time.Time{}, err
-	}
-
-	var lastDeveloper string
-	var lastCommitDate time.Time
-	err = commitIter.ForEach(func(c *object.Commit) error {
-		if c.Author.Name != excludeUser {
-			lastDeveloper = c.Author.Name
-			lastCommitDate = c.Committer.When
-			return fmt.Errorf("found") // Stop iteration
-		}
-		return nil
-	})
-	if err != nil && err.Error() != "found" {
-		return "", time.Time{}, err
-	}
-
-	if replacement, ok := replacements[lastDeveloper]; ok {
-		lastDeveloper = replacement
-	}
-
-	return lastDeveloper, lastCommitDate, nil
+func isGitRepo(path string) bool {
+	_, err := os.Stat(filepath.Join(path, ".git"))
+	return !os.IsNotExist(err)
 }
 
-// getTopDeveloper calculates the top developer and their commit percentage, excluding a specific user
-func getTopDeveloper(repo *git.Repository, excludeUser string, replacements map[string]string) (string, float64, error) {
-	commitIter, err := repo.Log(&git.LogOptions{})
-	if err != nil {
-		return "", 0, err
-	}
+// collectReleaseInfo reads the tags of a repository and compares its default branch with its latest tag
+// and with its develop branch. The commit counts need the ancestors of the default branch, which are
+// nil for shallow clones. A non-zero before date restricts the tags and the develop branch to what
+// existed before it.
+func collectReleaseInfo(logger *logger.Logger, repo *git.Repository, path string, branches []string, defaultBranch string, defaultAncestors map[plumbing.Hash]bool, roles []gitUtils.BranchRole, before time.Time) structs.ReleaseInfo {
+	release := structs.ReleaseInfo{DaysSinceRelease: -1}
 
-	developerCount := make(map[string]int)
-	totalCommits := 0
+	now := time.Now()
+	if !before.IsZero() {
+		now = before
+	}
 
-	err = commitIter.ForEach(func(c *object.Commit) error {
-		if c.Author.Name == excludeUser {
-			return nil
-		}
-		developerCount[c.Author.Name]++
-		totalCommits++
-		return nil
-	})
+	allTags, err := gitUtils.Tags(repo)
 	if err != nil {
-		return "", 0, err
+		logger.Warn("Failed to read tags in repository: %s [%s]", path, err)
 	}
-
-	var topDeveloper string
-	var maxCommits int
-	for developer, count := range developerCount {
-		if count > maxCommits {
-			topDeveloper = developer
-			maxCommits = count
+	// A point-in-time report only knows the tags created before its date
+	var tags []gitUtils.Tag
+	for _, tag := range allTags {
+		if tag.Date.Before(now) {
+			tags = append(tags, tag)
 		}
 	}
 
-	if replacement, ok := replacements[topDeveloper]; ok {
-		topDeveloper = replacement
-	}
-
-	percentage := calculateDeveloperPercentage(repo, topDeveloper)
-	return topDeveloper, percentage, nil
-}
-
-// calculateDeveloperPercentage calculates the percentage of commits made by a specific developer
-func calculateDeveloperPercentage(repo *git.Repository, developer string) float64 {
-	commitIter, err := repo.Log(&git.LogOptions{})
-	if err != nil {
-		return 0
-	}
-	defer commitIter.Close()
-
-	developerCount := 0
-	totalCommits := 0
+	if len(tags) > 0 {
+		latest := tags[0]
+		release.LatestTag = latest.Name
+		release.LatestTagSemver = gitUtils.IsSemver(latest.Name)
+		release.TagCount = len(tags)
+		release.LastReleaseDate = latest.Date
+		release.DaysSinceRelease = int(now.Sub(latest.Date).Hours() / 24)
 
-	err = commitIter.ForEach(func(c *object.Commit) error {
-		if c.Author.Name == developer {
-			developerCount++
+		if defaultAncestors != nil {
+			tagAncestors, err := gitUtils.Ancestors(repo, latest.Commit)
+			if err != nil {
+				logger.Warn("Failed to read tag: %s in repository: %s [%s]", latest.Name, path, err)
+			} else {
+				release.CommitsSinceTag, _ = gitUtils.AheadBehind(defaultAncestors, tagAncestors)
+			}
 		}
-		totalCommits++
-		return nil
-	})
-	if err != nil {
-		return 0
+	} else {
+		// Without any tag, nothing has been released
+		release.CommitsSinceTag = len(defaultAncestors)
 	}
 
-	if totalCommits == 0 {
-		return 0
+	if defaultAncestors == nil {
+		return release
 	}
-
-	percentage := (float64(developerCount) / float64(totalCommits)) * 100
-	roundedPercentage := math.Round(percentage*2) / 2
-
-	return roundedPercentage
-}
-
-func isGitRepo(path string) bool {
-	_, err := os.Stat(filepath.Join(path, ".git"))
-	return !os.IsNotExist(err)
-}
-
-func fileExistsIgnoreCase(repoPath, fileNameRegex string) bool {
-	// Compile the regular expression
-	regex, err := regexp.Compile(fileNameRegex) // (?i) makes the regex case-insensitive
-	if err != nil {
-		fmt.Printf("Error compiling regex: %v\n", err)
-		return false
-	}
-
-	err = filepath.Walk(repoPath, func(path string, info os.FileInfo, err error) error {
-		if err != nil {
-			return err
+	for _, branch := range branches {
+		if gitUtils.RoleOf(branch, defaultBranch, roles) != gitUtils.RoleDevelop {
+			continue
 		}
-		if !info.IsDir() && regex.MatchString(info.Name()) {
-			return fmt.Errorf("found")
+		developHash, err := gitUtils.BranchHashBefore(repo, branch, before)
+		if err == nil {
+			var developAncestors map[plumbing.Hash]bool
+			developAncestors, err = gitUtils.Ancestors(repo, developHash)
+			if err == nil {
+				release.DevelopAhead, release.DevelopBehind = gitUtils.AheadBehind(developAncestors, defaultAncestors)
+				release.DevelopDiverged = release.DevelopAhead > 0 && release.DevelopBehind > 0
+			}
 		}
-		return nil
-	})
-	return err != nil && strings.HasPrefix(err.Error(), "found")
-}
-
-func searchInFiles(repoPath, searchTermRegex string) bool {
-	found := false
-
-	// Compile the regular expression
-	regex, err := regexp.Compile(searchTermRegex)
-	if err != nil {
-		fmt.Printf("Error compiling regex: %v\n", err)
-		return false
-	}
-
-	err = filepath.Walk(repoPath, func(path string, info os.FileInfo, err error) error {
 		if err != nil {
-			return err
+			logger.Warn("Failed to compare branch: %s with %s in repository: %s [%s]", branch, defaultBranch, path, err)
 		}
-		if !info.IsDir() {
-			content, err := os.ReadFile(path)
-			if err != nil {
-				return err
-			}
-			if regex.MatchString(string(content)) {
-				found = true
-				return filepath.SkipDir // Stop walking the directory
-			}
-		}
-		return nil
-	})
-	if err != nil && err != filepath.SkipDir {
-		return false
+		break
 	}
-	return found
+
+	return release
+}
+
+// branchStatus classifies a branch from the age of its last commit at a date: abandoned after
+// abandonedDays, stale after staleDays, active otherwise
+func branchStatus(now, lastCommitDate time.Time, staleDays, abandonedDays int) string {
+	days := int(now.Sub(lastCommitDate).Hours() / 24)
+	switch {
+	case days >= abandonedDays:
+		return structs.BranchStatusAbandoned
+	case days >= staleDays:
+		return structs.BranchStatusStale
+	}
+	return structs.BranchStatusActive
 }
 
 func formatDuration(d time.Duration) string {
@@ -575,105 +953,58 @@ func formatDuration(d time.Duration) string {
 	return fmt.Sprintf("%d days", days)
 }
 
-// countCommits  counts the number of commits in a branch excluding those made by a specific user
-func countCommits(repo *git.Repository, excludedUser string) (int, error) {
-
-	commitIter, err := repo.Log(&git.LogOptions{})
-	totalCommits := 0
-	if err != nil {
-		return 0, err
-	}
-	defer commitIter.Close()
-
-	err = commitIter.ForEach(func(c *object.Commit) error {
-		if c.Author.Name != excludedUser {
-			totalCommits++
+// serviceValues collects the values extracted from each service, without duplicates and in order of appearance
+func serviceValues(services []structs.ServiceInfo, extract func(structs.ServiceInfo) []string) []string {
+	seen := make(map[string]bool)
+	var values []string
+	for _, service := range services {
+		for _, value := range extract(service) {
+			if value != "" && !seen[value] {
+				seen[value] = true
+				values = append(values, value)
+			}
 		}
-		return nil
-	})
-	if err != nil {
-		return 0, err
 	}
-
-	return totalCommits, nil
+	return values
 }
 
-// getHostLine reads a file (ignoring case) and extracts all values inside the Host lines
-func getHostLine(dirPath, fileName string) string {
-	// Find the file ignoring case
-	filePath, err := findFileIgnoreCase(dirPath, fileName)
-	if err != nil {
-		return ""
+// serviceImages returns the image reference of a service, with its tag when it has one
+func serviceImages(service structs.ServiceInfo) []string {
+	if service.Image == "" {
+		return nil
 	}
-
-	// Open the file
-	file, err := os.Open(filePath)
-	if err != nil {
-		return ""
-	}
-	defer file.Close()
-	// Read the file line by line
-
-	scanner := bufio.NewScanner(file)
-	for scanner.Scan() {
-		line := scanner.Text()
-		// Check if the line contains the Host keyword (ignoring case)
-		if strings.Contains(strings.ToLower(line), "host") {
-			// Extract all values inside the parentheses
-			start := 0
-			maxloop := 0
-			for {
-				start = strings.Index(line, "(")
-				end := strings.LastIndex(line, ")")
-				if start != -1 && end != -1 && start < end {
-					HostString := line[start+1 : end]
-					HostString = strings.Replace(HostString, ") Host (", " ", -1)
-					return HostString
-				}
-				if maxloop > 1000 {
-					break
-				} else {
-					maxloop++
-				}
-			}
-		}
+	if service.Tag == "" {
+		return []string{service.Image}
 	}
-
-	if err := scanner.Err(); err != nil {
-		return ""
+	// Digests ("sha256:...") are separated from the image name by "@" instead of ":"
+	if strings.Contains(service.Tag, ":") {
+		return []string{service.Image + "@" + service.Tag}
 	}
-	return ""
+	return []string{service.Image + ":" + service.Tag}
 }
 
-// findFileIgnoreCase finds a file in a directory ignoring case
-func findFileIgnoreCase(dirPath, fileName string) (string, error) {
-	err := filepath.Walk(dirPath, func(path string, info os.FileInfo, err error) error {
-		if err != nil {
-			return err
-		}
-		if strings.EqualFold(info.Name(), fileName) {
-			return fmt.Errorf("found: %s", path)
-		}
-		return nil
-	})
+// serviceHosts returns the hostnames routed to a service by Traefik
+func serviceHosts(service structs.ServiceInfo) []string {
+	return service.Hosts
+}
 
-	if err != nil && strings.HasPrefix(err.Error(), "found: ") {
-		return strings.TrimPrefix(err.Error(), "found: "), nil
-	}
+// serviceNetworks returns the networks a service is attached to
+func serviceNetworks(service structs.ServiceInfo) []string {
+	return service.Networks
+}
 
-	return "", fmt.Errorf("file not found: %s", fileName)
+// serviceRules returns the Traefik router rules of a service
+func serviceRules(service structs.ServiceInfo) []string {
+	return service.Rules
 }
 
-// getDockerComposeFileName retrieves the name of the docker-compose* file in the given directory
-func getDockerComposeFileName(dirPath string) string {
-	// Use filepath.Glob to find files that match the pattern docker-compose*
-	files, err := filepath.Glob(filepath.Join(dirPath, "docker-compose*"))
-	if err != nil {
-		return "docker-compose.yaml"
-	}
-	if len(files) == 0 {
-		return "docker-compose.yaml"
+// servicePublishedPorts returns the port mappings of a service that are published on the host
+func servicePublishedPorts(service structs.ServiceInfo) []string {
+	var ports []string
+	for _, port := range service.Ports {
+		if port.Published != "" {
+			ports = append(ports, port.String())
+		}
 	}
-	// Return the name of the first match
-	return filepath.Base(files[0])
+	return ports
 }
@@ -1,8 +1,6 @@
 package excel
 
 import (
-	"fmt"
-
 	styles "github.com/s3pweb/gitArchiveS3Report/utils/excel"
 	"github.com/s3pweb/gitArchiveS3Report/utils/structs"
 	"github.com/xuri/excelize/v2"
@@ -38,6 +36,10 @@ func CreateExcelFile(Branches []structs.BranchInfo) (*excelize.File, error) {
 	}
 
 	totalColumns := fixedColumns + maxFilesToSearch + maxTermsToSearch
+	lastColumn, err := excelize.ColumnNumberToName(max(totalColumns, 1))
+	if err != nil {
+		return nil, err
+	}
 
 	// Create header style
 	headerStyle, err := styles.CreateHeaderStyle(f)
@@ -49,13 +51,11 @@ func CreateExcelFile(Branches []structs.BranchInfo) (*excelize.File, error) {
 	sheets := []string{allBranchesSheet, mainBranchesSheet, developBranchesSheet}
 	for _, sheet := range sheets {
 		// Apply header style
-		f.SetCellStyle(sheet, "A1", fmt.Sprintf("%c1", 'A'+totalColumns-1), headerStyle)
+		f.SetCellStyle(sheet, "A1", lastColumn+"1", headerStyle)
 		f.SetRowHeight(sheet, 1, 40)
 
 		// Set column widths
-		for col := 'A'; col < 'A'+rune(totalColumns); col++ {
-			f.SetColWidth(sheet, string(col), string(col), 20)
-		}
+		f.SetColWidth(sheet, "A", lastColumn, 20)
 
 		// Freeze the first row
 		if err := f.SetPanes(sheet, `{
@@ -1,23 +1,27 @@
 package excel
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"path/filepath"
 	"time"
 
+	"github.com/s3pweb/gitArchiveS3Report/config"
+	gitUtils "github.com/s3pweb/gitArchiveS3Report/utils/git"
 	"github.com/s3pweb/gitArchiveS3Report/utils/logger"
 	"github.com/s3pweb/gitArchiveS3Report/utils/structs"
 )
 
 // ReportExcel generates an Excel report for Bitbucket repositories
 // Parameters:
+//   - ctx: Context whose cancellation stops the collection and writes a partial report
 //   - basePath: Base directory containing the repositories
 //   - cfg: Configuration object containing report settings
 //
 // Returns:
 //   - error: Any error encountered during report generation
-func ReportExcel(basePath, dirDest string, devSheets bool) error {
+func ReportExcel(ctx context.Context, basePath, dirDest string, devSheets bool) error {
 	logger, err := logger.NewLogger("ReportExcel", "info")
 	if err != nil {
 		return err
@@ -26,15 +30,28 @@ func ReportExcel(basePath, dirDest string, devSheets bool) error {
 	startTime := time.Now()
 	logger.Info("Starting Excel report generation...")
 
+	cfg := config.Get()
+	if !cfg.App.ReportAsOf.IsZero() {
+		logger.Info("Point-in-time report as of %s", cfg.App.ReportAsOf.Format("2006-01-02"))
+	}
+	if !cfg.App.ReportSince.IsZero() || !cfg.App.ReportUntil.IsZero() {
+		logger.Info("Counting the commits from %s to %s", formatWindowDate(cfg.App.ReportSince, "the first commit"), formatWindowDate(cfg.App.ReportUntil, "today"))
+	}
+
 	// Count total repositories before processing
 	entries, err := os.ReadDir(basePath)
 	if err != nil {
 		return fmt.Errorf("failed to read directory %s: %v", basePath, err)
 	}
 
-	totalRepos := 0
+	// Every folder of the workspace is accounted for in the Problems sheet
+	totalRepos, totalFolders := 0, 0
 	for _, entry := range entries {
-		if entry.IsDir() && isGitRepo(filepath.Join(basePath, entry.Name())) {
+		if !entry.IsDir() {
+			continue
+		}
+		totalFolders++
+		if isGitRepo(filepath.Join(basePath, entry.Name())) {
 			totalRepos++
 		}
 	}
@@ -42,7 +59,7 @@ func ReportExcel(basePath, dirDest string, devSheets bool) error {
 	logger.Info("Found %d total repositories to analyze", totalRepos)
 
 	// Collect branch information with progress tracking
-	branchesInfo, processedRepos, err := CollectBranchInfo(basePath, logger, totalRepos)
+	branchesInfo, problems, processedRepos, err := CollectBranchInfo(ctx, basePath, logger, totalRepos)
 	if err != nil {
 		if processedRepos < totalRepos {
 			logger.Warn("Processed %d/%d repositories before encountering error", processedRepos, totalRepos)
@@ -54,6 +71,10 @@ func ReportExcel(basePath, dirDest string, devSheets bool) error {
 		}
 	}
 
+	if ctx.Err() != nil {
+		logger.Warn("Report interrupted: writing a partial report, the repositories left out are in the Problems sheet")
+	}
+
 	// Count unique processed repositories
 	repoMap := make(map[string]bool)
 	for _, info := range branchesInfo {
@@ -66,17 +87,19 @@ func ReportExcel(basePath, dirDest string, devSheets bool) error {
 		return fmt.Errorf("failed to create Excel file: %v", err)
 	}
 
+	// The sheets group the branches by role: the default branch of each repository, and the
+	// branches matching BRANCH_ROLES
 	var mainBranches, developBranches []structs.BranchInfo
 	for _, branch := range branchesInfo {
-		if branch.BranchName == "main" || branch.BranchName == "origin/main" ||
-			branch.BranchName == "master" || branch.BranchName == "origin/master" {
+		switch branch.BranchRole {
+		case gitUtils.RoleMain:
 			mainBranches = append(mainBranches, branch)
-		} else if branch.BranchName == "develop" || branch.BranchName == "origin/develop" {
+		case gitUtils.RoleDevelop:
 			developBranches = append(developBranches, branch)
 		}
 	}
 
-	err = WriteBranchInfoToExcel(excelFile, branchesInfo, mainBranches, developBranches, devSheets)
+	err = WriteBranchInfoToExcel(excelFile, branchesInfo, mainBranches, developBranches, problems, totalFolders, devSheets)
 	if err != nil {
 		return fmt.Errorf("failed to write branch info to Excel: %v", err)
 	}
@@ -92,7 +115,7 @@ func ReportExcel(basePath, dirDest string, devSheets bool) error {
 
 	if processedRepos < totalRepos {
 		logger.Warn("Repositories successfully processed: %d/%d", processedReposCount, totalRepos)
-		logger.Warn("Some repositories could not be processed. Check logs for details.")
+		logger.Warn("Some repositories could not be processed. Check the Problems sheet for details.")
 	} else {
 		logger.Success("Repositories successfully processed: %d/%d", processedReposCount, totalRepos)
 	}
@@ -100,3 +123,11 @@ func ReportExcel(basePath, dirDest string, devSheets bool) error {
 	logger.Info("Total branches analyzed: %d", len(branchesInfo))
 	return nil
 }
+
+// formatWindowDate formats a bound of the report window, or describes an open bound
+func formatWindowDate(date time.Time, open string) string {
+	if date.IsZero() {
+		return open
+	}
+	return date.Format("2006-01-02")
+}
@@ -0,0 +1,274 @@
+package excel
+
+import (
+	"context"
+	"math"
+	"regexp"
+	"sort"
+	"time"
+
+	"github.com/ProtonMail/go-crypto/openpgp"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	gitUtils "github.com/s3pweb/gitArchiveS3Report/utils/git"
+	"github.com/s3pweb/gitArchiveS3Report/utils/languages"
+	"github.com/s3pweb/gitArchiveS3Report/utils/structs"
+)
+
+// historyWindow bounds the commits counted by the history statistics. A zero since or until
+// leaves that side of the window open. until is inclusive: it is the last day of the window.
+type historyWindow struct {
+	since time.Time
+	until time.Time
+}
+
+// contains tells whether a commit date is inside the window
+func (w historyWindow) contains(date time.Time) bool {
+	if !w.since.IsZero() && date.Before(w.since) {
+		return false
+	}
+	if !w.until.IsZero() && !date.Before(w.until.AddDate(0, 0, 1)) {
+		return false
+	}
+	return true
+}
+
+// end returns the end of the window, or now when the window is open
+func (w historyWindow) end() time.Time {
+	if w.until.IsZero() {
+		return time.Now()
+	}
+	return w.until
+}
+
+// historyOptions selects the commits of a history walk and how they are counted
+type historyOptions struct {
+	// ctx stops the walk when the repository times out or the report is interrupted
+	ctx context.Context
+	// excludeUser is the author of automated commits, left out of the developer statistics
+	excludeUser string
+	window      historyWindow
+	// months is the size of the activity window and churnDays the size of the churn window
+	months    int
+	churnDays int
+	// issueKey matches the issue keys referenced by the commit messages
+	issueKey *regexp.Regexp
+	// keyring holds the trusted keys of the commit signatures, and verified caches the verifications
+	// of the commits shared by several branches
+	keyring  openpgp.EntityList
+	verified map[plumbing.Hash]bool
+}
+
+// historyStats is what a single walk of the history of a branch collects
+type historyStats struct {
+	// lastDeveloper and lastCommitDate come from the most recent commit not made by the excluded user
+	lastDeveloper  string
+	lastCommitDate time.Time
+	// commitCount and authorCommits exclude the commits of the excluded user and those outside the window
+	commitCount   int
+	authorCommits map[string]int
+	// allAuthorCommits and totalCommits include the commits of every author inside the window
+	allAuthorCommits map[string]int
+	totalCommits     int
+	// monthly counts the commits, excluding the excluded user, of each month of the activity window
+	monthly []structs.MonthlyCount
+	// churn holds the lines added and removed by each author since the start of the churn window,
+	// merge commits and vendored or generated files excluded
+	churn map[string]structs.Churn
+	// messages counts the commit messages of each author following Conventional Commits or
+	// referencing an issue key, merge commits excluded
+	messages map[string]structs.MessageStats
+	// signatures counts the signed and verified commits of each author
+	signatures map[string]structs.SignatureStats
+}
+
+// walkHistory walks the history of a branch once, from its last commit. Author names are the raw names:
+// the replacements of DEVELOPERS_MAP are applied by the callers. Only the commits inside the
+// report window are counted, except for the last developer and the last commit date which
+// describe the branch itself. The activity window is made of the given number of months,
+// ending with the month of the end of the report window, and the churn is computed on the
+// commits of the churnDays days before the end of the report window.
+func walkHistory(repo *git.Repository, from plumbing.Hash, options historyOptions) (historyStats, error) {
+	excludeUser, window := options.excludeUser, options.window
+	churnSince := window.end().AddDate(0, 0, -options.churnDays)
+	stats := historyStats{
+		authorCommits:    make(map[string]int),
+		allAuthorCommits: make(map[string]int),
+		monthly:          activityWindow(window.end(), options.months),
+		churn:            make(map[string]structs.Churn),
+		messages:         make(map[string]structs.MessageStats),
+		signatures:       make(map[string]structs.SignatureStats),
+	}
+	monthIndex := make(map[string]int)
+	for i, month := range stats.monthly {
+		monthIndex[month.Month] = i
+	}
+
+	commitIter, err := repo.Log(&git.LogOptions{From: from})
+	if err != nil {
+		return stats, err
+	}
+	defer commitIter.Close()
+
+	err = commitIter.ForEach(func(c *object.Commit) error {
+		if err := options.ctx.Err(); err != nil {
+			return err
+		}
+		if c.Author.Name != excludeUser && stats.lastDeveloper == "" {
+			stats.lastDeveloper = c.Author.Name
+			stats.lastCommitDate = c.Committer.When
+		}
+		if !window.contains(c.Committer.When) {
+			return nil
+		}
+
+		stats.allAuthorCommits[c.Author.Name]++
+		stats.totalCommits++
+
+		if c.Author.Name == excludeUser {
+			return nil
+		}
+		stats.commitCount++
+		stats.authorCommits[c.Author.Name]++
+
+		if i, ok := monthIndex[c.Committer.When.Format("2006-01")]; ok {
+			stats.monthly[i].Commits++
+		}
+
+		signatures := stats.signatures[c.Author.Name]
+		signatures.Commits++
+		if gitUtils.SignatureKind(c) != "" {
+			signatures.Signed++
+			if options.verify(c) {
+				signatures.Verified++
+			}
+		}
+		stats.signatures[c.Author.Name] = signatures
+
+		if c.NumParents() <= 1 {
+			stats.messages[c.Author.Name] = addMessage(stats.messages[c.Author.Name], c.Message, options.issueKey)
+		}
+
+		if c.NumParents() <= 1 && c.Committer.When.After(churnSince) {
+			added, removed, err := commitChurn(c)
+			if err == nil {
+				churn := stats.churn[c.Author.Name]
+				churn.Commits++
+				churn.Added += added
+				churn.Removed += removed
+				stats.churn[c.Author.Name] = churn
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return stats, err
+	}
+	return stats, nil
+}
+
+// verify checks the signature of a commit against the keyring, once per commit
+func (o historyOptions) verify(c *object.Commit) bool {
+	if len(o.keyring) == 0 {
+		return false
+	}
+	if verified, ok := o.verified[c.Hash]; ok {
+		return verified
+	}
+	verified := gitUtils.VerifySignature(c, o.keyring)
+	if o.verified != nil {
+		o.verified[c.Hash] = verified
+	}
+	return verified
+}
+
+// commitChurn counts the lines added and removed by a commit, vendored and generated files excluded.
+// It fails on the first commit of a shallow clone, whose parent is missing.
+func commitChurn(c *object.Commit) (int, int, error) {
+	fileStats, err := c.Stats()
+	if err != nil {
+		return 0, 0, err
+	}
+	added, removed := 0, 0
+	for _, file := range fileStats {
+		if languages.IsVendored(file.Name) || languages.IsGenerated(file.Name, nil) {
+			continue
+		}
+		added += file.Addition
+		removed += file.Deletion
+	}
+	return added, removed, nil
+}
+
+// topDeveloper returns the author with the most commits, the excluded user left out.
+// Ties are broken by name so that the result does not depend on map ordering.
+func (h historyStats) topDeveloper() string {
+	var authors []string
+	for author := range h.authorCommits {
+		authors = append(authors, author)
+	}
+	sort.Strings(authors)
+
+	var topDeveloper string
+	var maxCommits int
+	for _, author := range authors {
+		if h.authorCommits[author] > maxCommits {
+			topDeveloper = author
+			maxCommits = h.authorCommits[author]
+		}
+	}
+	return topDeveloper
+}
+
+// percentage returns the share of all the commits made by a developer, rounded to 0.5
+func (h historyStats) percentage(developer string) float64 {
+	if h.totalCommits == 0 {
+		return 0
+	}
+	percentage := (float64(h.allAuthorCommits[developer]) / float64(h.totalCommits)) * 100
+	return math.Round(percentage*2) / 2
+}
+
+// activityWindow returns the months of the activity window with no commit, oldest first
+func activityWindow(now time.Time, months int) []structs.MonthlyCount {
+	if months <= 0 {
+		return nil
+	}
+	first := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location()).AddDate(0, -(months - 1), 0)
+	window := make([]structs.MonthlyCount, months)
+	for i := range window {
+		window[i].Month = first.AddDate(0, i, 0).Format("2006-01")
+	}
+	return window
+}
+
+// activityTrend compares the commits of the last three months of the window with the three months
+// before (or the two halves of a shorter window): rising above +20%, declining below -20%
+func activityTrend(monthly []structs.MonthlyCount) string {
+	period := len(monthly) / 2
+	if period > 3 {
+		period = 3
+	}
+	if period == 0 {
+		return structs.TrendInactive
+	}
+
+	recent, previous := 0, 0
+	for _, month := range monthly[len(monthly)-period:] {
+		recent += month.Commits
+	}
+	for _, month := range monthly[len(monthly)-2*period : len(monthly)-period] {
+		previous += month.Commits
+	}
+
+	switch {
+	case recent == 0 && previous == 0:
+		return structs.TrendInactive
+	case float64(recent) > float64(previous)*1.2:
+		return structs.TrendRising
+	case float64(recent) < float64(previous)*0.8:
+		return structs.TrendDeclining
+	}
+	return structs.TrendStable
+}
@@ -0,0 +1,157 @@
+package excel
+
+import (
+	"context"
+	"io"
+	"math"
+	"sort"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/s3pweb/gitArchiveS3Report/utils/languages"
+	"github.com/s3pweb/gitArchiveS3Report/utils/structs"
+)
+
+// Bases of the bus factor
+const (
+	busFactorCommits = "commits"
+	busFactorLines   = "lines"
+)
+
+// collectKnowledge computes the bus factor of a branch at a commit and the share of the
+// former employees, from the commits of each author or from the lines they last modified.
+// Author names are replaced with DEVELOPERS_MAP so that the aliases of a person are merged.
+func collectKnowledge(ctx context.Context, repo *git.Repository, tip plumbing.Hash, history historyStats, basis string, threshold float64, formerEmployees []string, excludeUser string, replacements map[string]string) (structs.KnowledgeInfo, error) {
+	shares := make(map[string]int)
+	if basis == busFactorLines {
+		lines, err := linesByAuthor(ctx, repo, tip, excludeUser)
+		if err != nil {
+			return structs.KnowledgeInfo{}, err
+		}
+		for author, count := range lines {
+			shares[replaceName(author, replacements)] += count
+		}
+	} else {
+		basis = busFactorCommits
+		for author, count := range history.authorCommits {
+			shares[replaceName(author, replacements)] += count
+		}
+	}
+
+	knowledge := structs.KnowledgeInfo{Basis: basis}
+	knowledge.BusFactor, knowledge.KeyAuthors = busFactor(shares, threshold)
+
+	total := 0
+	for _, count := range shares {
+		total += count
+	}
+	former := 0
+	for _, name := range formerEmployees {
+		if count, ok := shares[name]; ok && count > 0 {
+			former += count
+			knowledge.FormerAuthors = append(knowledge.FormerAuthors, name)
+		}
+	}
+	if total > 0 {
+		knowledge.FormerShare = math.Round(float64(former)/float64(total)*1000) / 10
+	}
+	return knowledge, nil
+}
+
+// busFactor returns the minimum number of authors who account for the threshold percentage
+// of the shares, and these authors, the largest share first
+func busFactor(shares map[string]int, threshold float64) (int, []string) {
+	var authors []string
+	total := 0
+	for author, count := range shares {
+		if count > 0 {
+			authors = append(authors, author)
+			total += count
+		}
+	}
+	if total == 0 {
+		return 0, nil
+	}
+	sort.Slice(authors, func(i, j int) bool {
+		if shares[authors[i]] != shares[authors[j]] {
+			return shares[authors[i]] > shares[authors[j]]
+		}
+		return authors[i] < authors[j]
+	})
+
+	covered := 0
+	for i, author := range authors {
+		covered += shares[author]
+		if float64(covered)/float64(total)*100 >= threshold {
+			return i + 1, authors[:i+1]
+		}
+	}
+	return len(authors), authors
+}
+
+// linesByAuthor blames every source file of a commit and counts
+// the surviving lines of each author. Vendored, generated and binary files are left out.
+// The blame stops between two files when the context is cancelled.
+func linesByAuthor(ctx context.Context, repo *git.Repository, tip plumbing.Hash, excludeUser string) (map[string]int, error) {
+	commit, err := repo.CommitObject(tip)
+	if err != nil {
+		return nil, err
+	}
+	files, err := commit.Files()
+	if err != nil {
+		return nil, err
+	}
+
+	lines := make(map[string]int)
+	err = files.ForEach(func(file *object.File) error {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if _, known := languages.Detect(file.Name); !known || languages.IsVendored(file.Name) {
+			return nil
+		}
+		if binary, err := file.IsBinary(); err != nil || binary {
+			return nil
+		}
+		if isGeneratedBlob(file) {
+			return nil
+		}
+
+		blame, err := git.Blame(commit, file.Name)
+		if err != nil {
+			return nil
+		}
+		for _, line := range blame.Lines {
+			if line.AuthorName != excludeUser {
+				lines[line.AuthorName]++
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return lines, nil
+}
+
+// isGeneratedBlob reads the beginning of a file to tell whether it is generated
+func isGeneratedBlob(file *object.File) bool {
+	reader, err := file.Reader()
+	if err != nil {
+		return false
+	}
+	defer reader.Close()
+
+	head := make([]byte, 1024)
+	n, _ := io.ReadFull(reader, head)
+	return languages.IsGenerated(file.Name, head[:n])
+}
+
+// replaceName applies the DEVELOPERS_MAP replacements to an author name
+func replaceName(name string, replacements map[string]string) string {
+	if replacement, ok := replacements[name]; ok {
+		return replacement
+	}
+	return name
+}
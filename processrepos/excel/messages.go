@@ -0,0 +1,71 @@
+package excel
+
+import (
+	"math"
+	"regexp"
+	"strings"
+
+	"github.com/s3pweb/gitArchiveS3Report/utils/structs"
+)
+
+// conventionalRegex matches the subject of a Conventional Commits message, e.g. "feat(api)!: add users"
+var conventionalRegex = regexp.MustCompile(`^(feat|fix|docs|style|refactor|perf|test|build|ci|chore|revert)(\([^()\s]+\))?!?: \S`)
+
+// anyIssueKey is the pattern of any issue key, such as ABC-123
+const anyIssueKey = `\b[A-Z][A-Z0-9]+-\d+\b`
+
+// issueKeyRegex returns the pattern of the issue keys: a key of the Jira project when
+// JIRA_PROJECT_KEY is set, even when it does not follow the usual form, or any other key
+func issueKeyRegex(projectKey string) *regexp.Regexp {
+	if projectKey = strings.TrimSpace(projectKey); projectKey != "" {
+		return regexp.MustCompile(`\b` + regexp.QuoteMeta(projectKey) + `-\d+\b|` + anyIssueKey)
+	}
+	return regexp.MustCompile(anyIssueKey)
+}
+
+// addMessage counts a commit message in the statistics of its author
+func addMessage(stats structs.MessageStats, message string, issueKey *regexp.Regexp) structs.MessageStats {
+	subject := strings.TrimSpace(strings.SplitN(strings.TrimSpace(message), "\n", 2)[0])
+	conventional := conventionalRegex.MatchString(subject)
+	hasIssueKey := issueKey != nil && issueKey.MatchString(message)
+
+	stats.Commits++
+	if conventional {
+		stats.Conventional++
+	}
+	if hasIssueKey {
+		stats.IssueKey++
+	}
+	// The log is walked from the most recent commit, so the first offending subject is kept
+	if (!conventional || !hasIssueKey) && stats.Example == "" {
+		stats.Example = subject
+	}
+	return stats
+}
+
+// mergeMessages adds the statistics of another author, keeping the first example
+func mergeMessages(stats, other structs.MessageStats) structs.MessageStats {
+	stats.Commits += other.Commits
+	stats.Conventional += other.Conventional
+	stats.IssueKey += other.IssueKey
+	if stats.Example == "" {
+		stats.Example = other.Example
+	}
+	return stats
+}
+
+// commitShare returns the percentage of the commits that match, rounded to 0.1
+func commitShare(matching, commits int) float64 {
+	if commits == 0 {
+		return 0
+	}
+	return math.Round(float64(matching)/float64(commits)*1000) / 10
+}
+
+// mergeSignatures adds the signature statistics of another author
+func mergeSignatures(stats, other structs.SignatureStats) structs.SignatureStats {
+	stats.Commits += other.Commits
+	stats.Signed += other.Signed
+	stats.Verified += other.Verified
+	return stats
+}
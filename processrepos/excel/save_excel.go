@@ -5,6 +5,7 @@ import (
 	"path/filepath"
 	"time"
 
+	"github.com/s3pweb/gitArchiveS3Report/config"
 	"github.com/s3pweb/gitArchiveS3Report/utils/logger"
 	"github.com/xuri/excelize/v2"
 )
@@ -18,6 +19,11 @@ func SaveExcelFile(f *excelize.File, workspacePath, outputDir string, logger *lo
 
 	fileName := fmt.Sprintf("%s_report_%s_%s.xlsx", workspace, dateStr, hourStr)
 
+	// A point-in-time report is named after the date it describes
+	if asOf := config.Get().App.ReportAsOf; !asOf.IsZero() {
+		fileName = fmt.Sprintf("%s_report_as_of_%s.xlsx", workspace, asOf.Format("2006-01-02"))
+	}
+
 	excelFileName := filepath.Join(outputDir, fileName)
 	if err := f.SaveAs(excelFileName); err != nil {
 		return err
@@ -0,0 +1,113 @@
+package excel
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	gitUtils "github.com/s3pweb/gitArchiveS3Report/utils/git"
+	"github.com/s3pweb/gitArchiveS3Report/utils/logger"
+	"github.com/s3pweb/gitArchiveS3Report/utils/structs"
+)
+
+// largeFilesLimit is the number of largest files kept from the tree and from the history of a repository,
+// and largeFileMinSize the size under which a file is too small to be listed
+const (
+	largeFilesLimit  = 10
+	largeFileMinSize = 100 * 1024
+)
+
+// collectSizeInfo measures a repository on disk and lists its largest files, in the tree of the commit
+// tip and in the whole history, before the given date when it is not zero. Files larger than
+// thresholdMB are flagged as files that should be tracked with Git LFS. Files under 100 KB are not listed.
+func collectSizeInfo(ctx context.Context, logger *logger.Logger, repo *git.Repository, path string, tip plumbing.Hash, before time.Time, thresholdMB float64) structs.SizeInfo {
+	var size structs.SizeInfo
+	threshold := int64(thresholdMB * 1024 * 1024)
+
+	diskSize, packSize, err := gitUtils.DiskSize(path)
+	if err != nil {
+		logger.Warn("Failed to measure repository: %s [%s]", path, err)
+	}
+	size.DiskSizeMB = toMB(diskSize)
+	size.PackSizeMB = toMB(packSize)
+
+	size.ObjectCount, err = gitUtils.ObjectCount(ctx, repo)
+	if err != nil {
+		logger.Warn("Failed to count the objects of repository: %s [%s]", path, err)
+	}
+
+	files := make(map[plumbing.Hash]*structs.LargeFile)
+	var order []plumbing.Hash
+	add := func(blob gitUtils.Blob, inTree bool) {
+		if blob.Size < largeFileMinSize {
+			return
+		}
+		file, ok := files[blob.Hash]
+		if !ok {
+			file = &structs.LargeFile{
+				Path:    blob.Path,
+				SizeMB:  toMB(blob.Size),
+				OverLFS: thresholdMB > 0 && blob.Size > threshold,
+			}
+			files[blob.Hash] = file
+			order = append(order, blob.Hash)
+		}
+		if inTree {
+			file.InTree = true
+		}
+		if !blob.Commit.IsZero() {
+			file.Commit = blob.Commit.String()[:8]
+			file.Date = blob.Date
+		}
+	}
+
+	if !tip.IsZero() {
+		treeFiles, err := gitUtils.TreeFiles(ctx, repo, tip)
+		if err != nil {
+			logger.Warn("Failed to read the files of repository: %s [%s]", path, err)
+		}
+		for i, blob := range treeFiles {
+			if thresholdMB > 0 && blob.Size > threshold {
+				size.FilesOverLFS++
+			}
+			if i < largeFilesLimit {
+				add(blob, true)
+			}
+		}
+	}
+
+	history, err := gitUtils.LargestBlobs(ctx, repo, largeFileMinSize, largeFilesLimit, before)
+	if err != nil {
+		logger.Warn("Failed to read the history of repository: %s [%s]", path, err)
+	}
+	for _, blob := range history {
+		add(blob, false)
+	}
+
+	for _, hash := range order {
+		size.LargeFiles = append(size.LargeFiles, *files[hash])
+	}
+	sort.SliceStable(size.LargeFiles, func(i, j int) bool {
+		return size.LargeFiles[i].SizeMB > size.LargeFiles[j].SizeMB
+	})
+	return size
+}
+
+// largestFile describes the largest file of the tree of the default branch, e.g. "assets/video.mp4 (52.30 MB)"
+func largestFile(size structs.SizeInfo) string {
+	for _, file := range size.LargeFiles {
+		if file.InTree {
+			return fmt.Sprintf("%s (%.2f MB)", file.Path, file.SizeMB)
+		}
+	}
+	return ""
+}
+
+// toMB converts a number of bytes to megabytes, rounded to 0.01
+func toMB(bytes int64) float64 {
+	return math.Round(float64(bytes)/1024/1024*100) / 100
+}
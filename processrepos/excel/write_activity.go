@@ -0,0 +1,156 @@
+package excel
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/s3pweb/gitArchiveS3Report/config"
+	styles "github.com/s3pweb/gitArchiveS3Report/utils/excel"
+	"github.com/s3pweb/gitArchiveS3Report/utils/structs"
+	"github.com/xuri/excelize/v2"
+)
+
+// writeActivitySheet writes the monthly commits of the main branch of each repository over the
+// activity window, the most active repositories first, below the workspace total. A column chart
+// shows the workspace total and a line chart the ACTIVITY_TOP_N most active repositories.
+func writeActivitySheet(f *excelize.File, branchesInfo []structs.BranchInfo) error {
+	cfg := config.Get()
+
+	sheet := "Activity"
+	f.NewSheet(sheet)
+
+	repos := primaryBranches(branchesInfo)
+	if len(repos) == 0 || len(repos[0].MonthlyCommits) == 0 {
+		return nil
+	}
+	months := repos[0].MonthlyCommits
+
+	sort.SliceStable(repos, func(i, j int) bool {
+		return totalCommits(repos[i].MonthlyCommits) > totalCommits(repos[j].MonthlyCommits)
+	})
+
+	headerStyle, err := styles.CreateHeaderStyle(f)
+	if err != nil {
+		return err
+	}
+	cellStyle, err := styles.CreateCellStyle(f)
+	if err != nil {
+		return err
+	}
+	risingStyle, err := styles.HighCountStyle(f)
+	if err != nil {
+		return err
+	}
+	decliningStyle, err := styles.LowCountStyle(f)
+	if err != nil {
+		return err
+	}
+
+	// Header: repository, one column per month, total and trend.
+	// The window can be wider than 26 columns, so cells are named with excelize.
+	headers := []string{"REPOSITORY"}
+	for _, month := range months {
+		headers = append(headers, month.Month)
+	}
+	headers = append(headers, "TOTAL", "TREND")
+	for i, header := range headers {
+		cell, _ := excelize.CoordinatesToCellName(i+1, 1)
+		f.SetCellValue(sheet, cell, header)
+		f.SetCellStyle(sheet, cell, cell, headerStyle)
+	}
+	lastMonthCol, _ := excelize.ColumnNumberToName(len(months) + 1)
+	trendCol, _ := excelize.ColumnNumberToName(len(months) + 3)
+	f.SetColWidth(sheet, "A", "A", 30)
+	f.SetColWidth(sheet, "B", trendCol, 12)
+	f.SetRowHeight(sheet, 1, 40)
+
+	// Workspace total on the first data row
+	workspace := make([]structs.MonthlyCount, len(months))
+	copy(workspace, months)
+	for i := range workspace {
+		workspace[i].Commits = 0
+		for _, repo := range repos {
+			if i < len(repo.MonthlyCommits) {
+				workspace[i].Commits += repo.MonthlyCommits[i].Commits
+			}
+		}
+	}
+
+	writeActivityRow := func(row int, name string, monthly []structs.MonthlyCount) {
+		trend := activityTrend(monthly)
+		values := []interface{}{name}
+		for _, month := range monthly {
+			values = append(values, month.Commits)
+		}
+		values = append(values, totalCommits(monthly), trend)
+
+		for i, value := range values {
+			cell, _ := excelize.CoordinatesToCellName(i+1, row)
+			f.SetCellValue(sheet, cell, value)
+			f.SetCellStyle(sheet, cell, cell, cellStyle)
+		}
+
+		trendCell := fmt.Sprintf("%s%d", trendCol, row)
+		switch trend {
+		case structs.TrendRising:
+			f.SetCellStyle(sheet, trendCell, trendCell, risingStyle)
+		case structs.TrendDeclining:
+			f.SetCellStyle(sheet, trendCell, trendCell, decliningStyle)
+		}
+		f.SetRowHeight(sheet, row, 30)
+	}
+
+	writeActivityRow(2, "WORKSPACE", workspace)
+	for i, repo := range repos {
+		writeActivityRow(i+3, repo.RepoName, repo.MonthlyCommits)
+	}
+
+	// Charts below the table
+	chartRow := len(repos) + 5
+	categories := fmt.Sprintf("%s!$B$1:$%s$1", sheet, lastMonthCol)
+
+	err = f.AddChart(sheet, fmt.Sprintf("A%d", chartRow), fmt.Sprintf(`{
+		"type": "col",
+		"series": [{"name": "%s!$A$2", "categories": "%s", "values": "%s!$B$2:$%s$2"}],
+		"title": {"name": "Workspace commits per month"},
+		"legend": {"none": true},
+		"dimension": {"width": 900, "height": 320}
+	}`, sheet, categories, sheet, lastMonthCol))
+	if err != nil {
+		return err
+	}
+
+	topN := cfg.App.ActivityTopN
+	if topN > len(repos) {
+		topN = len(repos)
+	}
+	if topN <= 0 {
+		return nil
+	}
+	series := ""
+	for i := 0; i < topN; i++ {
+		row := i + 3
+		if i > 0 {
+			series += ","
+		}
+		series += fmt.Sprintf(`{"name": "%s!$A$%d", "categories": "%s", "values": "%s!$B$%d:$%s$%d"}`,
+			sheet, row, categories, sheet, row, lastMonthCol, row)
+	}
+
+	return f.AddChart(sheet, fmt.Sprintf("A%d", chartRow+18), fmt.Sprintf(`{
+		"type": "line",
+		"series": [%s],
+		"title": {"name": "Commits per month of the %d most active repositories"},
+		"legend": {"position": "right"},
+		"dimension": {"width": 900, "height": 360}
+	}`, series, topN))
+}
+
+// totalCommits sums the commits of an activity window
+func totalCommits(monthly []structs.MonthlyCount) int {
+	total := 0
+	for _, month := range monthly {
+		total += month.Commits
+	}
+	return total
+}
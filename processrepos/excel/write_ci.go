@@ -0,0 +1,67 @@
+package excel
+
+import (
+	"fmt"
+	"strings"
+
+	styles "github.com/s3pweb/gitArchiveS3Report/utils/excel"
+	"github.com/s3pweb/gitArchiveS3Report/utils/structs"
+	"github.com/xuri/excelize/v2"
+)
+
+// writeCISheet writes the Bitbucket Pipelines analysis of the main branch of each repository
+func writeCISheet(f *excelize.File, branchesInfo []structs.BranchInfo) error {
+	sheet := "CI"
+	f.NewSheet(sheet)
+
+	headers := []string{"REPOSITORY", "BRANCH", "PIPELINES", "IMAGES", "TESTS", "LINT", "SONAR", "DEPLOYMENTS", "PIPES", "GAPS"}
+	for i, header := range headers {
+		col := 'A' + rune(i)
+		styles.SetOneHeader(f, sheet, header, i+1)
+		f.SetColWidth(sheet, string(col), string(col), 25)
+	}
+	f.SetColWidth(sheet, "J", "J", 60)
+	f.SetRowHeight(sheet, 1, 40)
+
+	cellStyle, err := styles.CreateCellStyle(f)
+	if err != nil {
+		return err
+	}
+	trueStyle, err := styles.TrueCells(f)
+	if err != nil {
+		return err
+	}
+	falseStyle, err := styles.FalseCells(f)
+	if err != nil {
+		return err
+	}
+
+	row := 2
+	for _, branch := range primaryBranches(branchesInfo) {
+		ci := branch.CI
+		writeRow(f, sheet, row, []interface{}{
+			branch.RepoName,
+			branch.BranchName,
+			strings.Join(ci.Pipelines, ", "),
+			strings.Join(ci.Images, ", "),
+			strings.ToUpper(fmt.Sprintf("%v", ci.HasTests)),
+			strings.ToUpper(fmt.Sprintf("%v", ci.HasLint)),
+			strings.ToUpper(fmt.Sprintf("%v", ci.HasSonar)),
+			strings.Join(ci.Deployments, ", "),
+			strings.Join(ci.Pipes, ", "),
+			strings.Join(ci.Gaps, ", "),
+		}, cellStyle)
+
+		for col, value := range map[string]bool{"E": ci.HasTests, "F": ci.HasLint, "G": ci.HasSonar} {
+			cell := fmt.Sprintf("%s%d", col, row)
+			if value {
+				f.SetCellStyle(sheet, cell, cell, trueStyle)
+			} else {
+				f.SetCellStyle(sheet, cell, cell, falseStyle)
+			}
+		}
+		row++
+	}
+
+	return nil
+}
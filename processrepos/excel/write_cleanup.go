@@ -0,0 +1,101 @@
+package excel
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	styles "github.com/s3pweb/gitArchiveS3Report/utils/excel"
+	"github.com/s3pweb/gitArchiveS3Report/utils/structs"
+	"github.com/xuri/excelize/v2"
+)
+
+// writeCleanupSheet writes the branches that can be deleted: the branches already merged into
+// the default branch of their repository, and the stale and abandoned branches.
+// Abandoned branches are in red and stale branches in orange.
+func writeCleanupSheet(f *excelize.File, branchesInfo []structs.BranchInfo) error {
+	sheet := "Branch cleanup candidates"
+	f.NewSheet(sheet)
+
+	headers := []string{"REPOSITORY", "BRANCH", "DEFAULT BRANCH", "LAST COMMIT", "DAYS SINCE LAST COMMIT", "LAST DEVELOPER", "STATUS", "MERGED", "AHEAD", "BEHIND", "REASON"}
+	for i, header := range headers {
+		col := 'A' + rune(i)
+		styles.SetOneHeader(f, sheet, header, i+1)
+		f.SetColWidth(sheet, string(col), string(col), 25)
+	}
+	f.SetColWidth(sheet, "K", "K", 50)
+	f.SetRowHeight(sheet, 1, 40)
+
+	cellStyle, err := styles.CreateCellStyle(f)
+	if err != nil {
+		return err
+	}
+	abandonedStyle, err := styles.LowCountStyle(f)
+	if err != nil {
+		return err
+	}
+	staleStyle, err := styles.MediumCountStyle(f)
+	if err != nil {
+		return err
+	}
+
+	var candidates []structs.BranchInfo
+	for _, branch := range branchesInfo {
+		if branch.BranchName == branch.DefaultBranch {
+			continue
+		}
+		if branch.IsMerged || branch.BranchStatus != structs.BranchStatusActive {
+			candidates = append(candidates, branch)
+		}
+	}
+
+	// Merged branches first, then the oldest branches
+	sort.SliceStable(candidates, func(i, j int) bool {
+		if candidates[i].RepoName != candidates[j].RepoName {
+			return strings.ToLower(candidates[i].RepoName) < strings.ToLower(candidates[j].RepoName)
+		}
+		if candidates[i].IsMerged != candidates[j].IsMerged {
+			return candidates[i].IsMerged
+		}
+		return candidates[i].LastCommitDate.Before(candidates[j].LastCommitDate)
+	})
+
+	row := 2
+	for _, branch := range candidates {
+		days := int(time.Since(branch.LastCommitDate).Hours() / 24)
+
+		var reasons []string
+		if branch.IsMerged {
+			reasons = append(reasons, fmt.Sprintf("merged into %s", branch.DefaultBranch))
+		}
+		if branch.BranchStatus != structs.BranchStatusActive {
+			reasons = append(reasons, fmt.Sprintf("%s: no commit for %d days", branch.BranchStatus, days))
+		}
+
+		style := cellStyle
+		switch branch.BranchStatus {
+		case structs.BranchStatusAbandoned:
+			style = abandonedStyle
+		case structs.BranchStatusStale:
+			style = staleStyle
+		}
+
+		writeRow(f, sheet, row, []interface{}{
+			branch.RepoName,
+			branch.BranchName,
+			branch.DefaultBranch,
+			branch.LastCommitDate.Format("2006-01-02 15:04"),
+			days,
+			branch.LastDeveloper,
+			branch.BranchStatus,
+			strings.ToUpper(fmt.Sprintf("%v", branch.IsMerged)),
+			branch.Ahead,
+			branch.Behind,
+			strings.Join(reasons, ", "),
+		}, style)
+		row++
+	}
+
+	return nil
+}
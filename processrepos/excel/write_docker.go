@@ -0,0 +1,78 @@
+package excel
+
+import (
+	"sort"
+
+	styles "github.com/s3pweb/gitArchiveS3Report/utils/excel"
+	"github.com/s3pweb/gitArchiveS3Report/utils/structs"
+	"github.com/xuri/excelize/v2"
+)
+
+// severityRank orders severities from the most to the least important
+var severityRank = map[string]int{
+	structs.SeverityCritical: 0,
+	structs.SeverityHigh:     1,
+	structs.SeverityMedium:   2,
+	structs.SeverityLow:      3,
+}
+
+// writeDockerFindingsSheet writes the Dockerfile findings of the main branch of each repository,
+// most severe first, with high and critical findings in red and medium findings in orange
+func writeDockerFindingsSheet(f *excelize.File, branchesInfo []structs.BranchInfo) error {
+	sheet := "Docker findings"
+	f.NewSheet(sheet)
+
+	headers := []string{"REPOSITORY", "BRANCH", "FILE", "LINE", "RULE", "SEVERITY", "MESSAGE", "BASE IMAGES"}
+	for i, header := range headers {
+		col := 'A' + rune(i)
+		styles.SetOneHeader(f, sheet, header, i+1)
+		f.SetColWidth(sheet, string(col), string(col), 25)
+	}
+	f.SetColWidth(sheet, "G", "G", 70)
+	f.SetRowHeight(sheet, 1, 40)
+
+	cellStyle, err := styles.CreateCellStyle(f)
+	if err != nil {
+		return err
+	}
+	highStyle, err := styles.LowCountStyle(f)
+	if err != nil {
+		return err
+	}
+	mediumStyle, err := styles.MediumCountStyle(f)
+	if err != nil {
+		return err
+	}
+
+	row := 2
+	for _, branch := range primaryBranches(branchesInfo) {
+		findings := append([]structs.DockerFinding(nil), branch.DockerFindings...)
+		sort.SliceStable(findings, func(i, j int) bool {
+			return severityRank[findings[i].Severity] < severityRank[findings[j].Severity]
+		})
+
+		for _, finding := range findings {
+			style := cellStyle
+			switch finding.Severity {
+			case structs.SeverityCritical, structs.SeverityHigh:
+				style = highStyle
+			case structs.SeverityMedium:
+				style = mediumStyle
+			}
+
+			writeRow(f, sheet, row, []interface{}{
+				branch.RepoName,
+				branch.BranchName,
+				finding.File,
+				finding.Line,
+				finding.Rule,
+				finding.Severity,
+				finding.Message,
+				branch.BaseImages,
+			}, style)
+			row++
+		}
+	}
+
+	return nil
+}
@@ -14,12 +14,14 @@ import (
 
 	"github.com/s3pweb/gitArchiveS3Report/config"
 	styles "github.com/s3pweb/gitArchiveS3Report/utils/excel"
+	"github.com/s3pweb/gitArchiveS3Report/utils/licenses"
+	"github.com/s3pweb/gitArchiveS3Report/utils/rules"
 	"github.com/s3pweb/gitArchiveS3Report/utils/structs"
 	"github.com/xuri/excelize/v2"
 	"golang.org/x/text/unicode/norm"
 )
 
-func WriteBranchInfoToExcel(f *excelize.File, allBranches, mainBranches, developBranches []structs.BranchInfo, includeDevSheets bool) error {
+func WriteBranchInfoToExcel(f *excelize.File, allBranches, mainBranches, developBranches []structs.BranchInfo, problems []structs.RepoProblem, folders int, includeDevSheets bool) error {
 	allBranchesSheet := "Branches"
 	mainBranchesSheet := "Main Branches"
 	developBranchesSheet := "Develop Branches"
@@ -51,6 +53,71 @@ func WriteBranchInfoToExcel(f *excelize.File, allBranches, mainBranches, develop
 		return err
 	}
 
+	err = writeLanguagesSheet(f, allBranches)
+	if err != nil {
+		return err
+	}
+
+	err = writeServicesSheet(f, allBranches)
+	if err != nil {
+		return err
+	}
+
+	err = writeInventorySheet(f, allBranches)
+	if err != nil {
+		return err
+	}
+
+	err = writeDockerFindingsSheet(f, allBranches)
+	if err != nil {
+		return err
+	}
+
+	err = writeCISheet(f, allBranches)
+	if err != nil {
+		return err
+	}
+
+	err = writeActivitySheet(f, allBranches)
+	if err != nil {
+		return err
+	}
+
+	err = writeKnowledgeSheet(f, allBranches)
+	if err != nil {
+		return err
+	}
+
+	err = writeReleasesSheet(f, allBranches)
+	if err != nil {
+		return err
+	}
+
+	err = writeCleanupSheet(f, allBranches)
+	if err != nil {
+		return err
+	}
+
+	err = writeSignaturesSheet(f, allBranches)
+	if err != nil {
+		return err
+	}
+
+	err = writeSizeSheet(f, allBranches)
+	if err != nil {
+		return err
+	}
+
+	err = writeSummarySheet(f, allBranches)
+	if err != nil {
+		return err
+	}
+
+	err = writeProblemsSheet(f, allBranches, problems, folders)
+	if err != nil {
+		return err
+	}
+
 	if includeDevSheets {
 		err = createDeveloperSheets(f, allBranches)
 		if err != nil {
@@ -68,17 +135,19 @@ func writeDataToSheet(f *excelize.File, sheet string, branchesInfo []structs.Bra
 	columns = append(columns, cfg.App.FilesToSearch...)
 	columns = append(columns, cfg.App.TermsToSearch...)
 	columns = append(columns, cfg.App.ForbiddenFiles...)
+	columns = append(columns, rules.CustomIDs(cfg.App.Rules)...)
 
 	sortBranchesByLastCommit(branchesInfo)
-	nbrcolumn := 'A'
+	nbrcolumn := 1
 
 	// Create maps to store totals for terms and files
 	termTotals := make(map[string]int)
 	fileTotals := make(map[string]int)
 	repoCount := countUniqueRepos(branchesInfo)
 
-	// Create maps to store totals for forbidden files
+	// Create maps to store totals for forbidden files and passed rules
 	forbiddenFileTotals := make(map[string]int)
+	ruleTotals := make(map[string]int)
 
 	// Initialize maps for terms and files
 	for _, term := range cfg.App.TermsToSearch {
@@ -124,7 +193,7 @@ func writeDataToSheet(f *excelize.File, sheet string, branchesInfo []structs.Bra
 				}
 
 				// If no element to count, write 0/0
-				cell := fmt.Sprintf("%c%d", nbrcolumn, row)
+				cell, _ := excelize.CoordinatesToCellName(nbrcolumn, row)
 				if denominator == 0 {
 					f.SetCellValue(sheet, cell, "0/0")
 				} else {
@@ -144,7 +213,7 @@ func writeDataToSheet(f *excelize.File, sheet string, branchesInfo []structs.Bra
 				}
 
 				// Write count of forbidden files
-				cell := fmt.Sprintf("%c%d", nbrcolumn, row)
+				cell, _ := excelize.CoordinatesToCellName(nbrcolumn, row)
 				if denominator == 0 {
 					f.SetCellValue(sheet, cell, "0/0")
 				} else {
@@ -172,11 +241,14 @@ func writeDataToSheet(f *excelize.File, sheet string, branchesInfo []structs.Bra
 			if val, exists := branchInfo.ForbiddenFiles[column]; exists && val {
 				forbiddenFileTotals[column]++
 			}
+			if result, exists := branchInfo.RuleResults[column]; exists && result.Passed {
+				ruleTotals[column]++
+			}
 			row++
 		}
 
 		// Add totals row after all data
-		cell := fmt.Sprintf("%c%d", nbrcolumn, row)
+		cell, _ := excelize.CoordinatesToCellName(nbrcolumn, row)
 		cellStyle, _ := styles.CreateCellStyle(f)
 
 		// Write totals for terms and files
@@ -188,13 +260,17 @@ func writeDataToSheet(f *excelize.File, sheet string, branchesInfo []structs.Bra
 			percentage := float64(fileTotals[column]) / float64(repoCount) * 100
 			f.SetCellValue(sheet, cell, fmt.Sprintf("%d/%d (%.1f%%)", fileTotals[column], repoCount, percentage))
 			f.SetCellStyle(sheet, cell, cell, cellStyle)
-		} else if nbrcolumn == 'H' {
+		} else if nbrcolumn == 8 {
 			f.SetCellValue(sheet, cell, "TOTAL")
 			f.SetCellStyle(sheet, cell, cell, cellStyle)
 		} else if forbiddenFileTotals[column] > 0 {
 			percentage := float64(forbiddenFileTotals[column]) / float64(repoCount) * 100
 			f.SetCellValue(sheet, cell, fmt.Sprintf("%d/%d (%.1f%%)", forbiddenFileTotals[column], repoCount, percentage))
 			f.SetCellStyle(sheet, cell, cell, cellStyle)
+		} else if ruleTotals[column] > 0 {
+			percentage := float64(ruleTotals[column]) / float64(repoCount) * 100
+			f.SetCellValue(sheet, cell, fmt.Sprintf("%d/%d (%.1f%%)", ruleTotals[column], repoCount, percentage))
+			f.SetCellStyle(sheet, cell, cell, cellStyle)
 		}
 
 		f.SetRowHeight(sheet, row, 30)
@@ -211,8 +287,58 @@ func countUniqueRepos(branchesInfo []structs.BranchInfo) int {
 	return len(repos)
 }
 
-func writeFieldToColumn(f *excelize.File, sheet string, row int, fieldName string, col rune, branchInfo interface{}) error {
+// complianceFields are the boolean fields of BranchInfo where TRUE is good and FALSE is bad
+var complianceFields = map[string]bool{
+	"CIHasTests":      true,
+	"CIHasLint":       true,
+	"CIHasSonar":      true,
+	"TipSigned":       true,
+	"HasReadme":       true,
+	"HasDocsFolder":   true,
+	"HasChangelog":    true,
+	"HasCodeOwners":   true,
+	"CodeOwnersValid": true,
+	"HasTests":        true,
+}
+
+// primaryBranches returns one branch per repository: its default branch when it has one,
+// otherwise its most recently updated branch
+func primaryBranches(branchesInfo []structs.BranchInfo) []structs.BranchInfo {
+	selected := make(map[string]structs.BranchInfo)
+	var repoNames []string
+
+	for _, branch := range branchesInfo {
+		current, exists := selected[branch.RepoName]
+		if !exists {
+			repoNames = append(repoNames, branch.RepoName)
+			selected[branch.RepoName] = branch
+			continue
+		}
+		if current.IsDefault {
+			continue
+		}
+		if branch.IsDefault || branch.LastCommitDate.After(current.LastCommitDate) {
+			selected[branch.RepoName] = branch
+		}
+	}
+
+	sort.Slice(repoNames, func(i, j int) bool {
+		return strings.ToLower(repoNames[i]) < strings.ToLower(repoNames[j])
+	})
+
+	primaries := make([]structs.BranchInfo, 0, len(repoNames))
+	for _, repoName := range repoNames {
+		primaries = append(primaries, selected[repoName])
+	}
+	return primaries
+}
+
+func writeFieldToColumn(f *excelize.File, sheet string, row int, fieldName string, col int, branchInfo interface{}) error {
 	cfg := config.Get()
+	cell, err := excelize.CoordinatesToCellName(col, row)
+	if err != nil {
+		return err
+	}
 
 	cellStyle, err := styles.CreateCellStyle(f)
 	if err != nil {
@@ -248,33 +374,43 @@ func writeFieldToColumn(f *excelize.File, sheet string, row int, fieldName strin
 		branchInfoTyped := branchInfo.(structs.BranchInfo)
 		// Check specifically in FilesToSearch and TermsToSearch maps
 		if val, exists := branchInfoTyped.FilesToSearch[fieldName]; exists {
-			f.SetCellValue(sheet, fmt.Sprintf("%c%d", col, row), strings.ToUpper(fmt.Sprintf("%v", val)))
+			f.SetCellValue(sheet, cell, strings.ToUpper(fmt.Sprintf("%v", val)))
 			if val {
-				f.SetCellStyle(sheet, fmt.Sprintf("%c%d", col, row), fmt.Sprintf("%c%d", col, row), trueStyle)
+				f.SetCellStyle(sheet, cell, cell, trueStyle)
 			} else {
-				f.SetCellStyle(sheet, fmt.Sprintf("%c%d", col, row), fmt.Sprintf("%c%d", col, row), falseStyle)
+				f.SetCellStyle(sheet, cell, cell, falseStyle)
 			}
 			f.SetRowHeight(sheet, row, 30)
 			return nil
 		}
 		if val, exists := branchInfoTyped.TermsToSearch[fieldName]; exists {
-			f.SetCellValue(sheet, fmt.Sprintf("%c%d", col, row), strings.ToUpper(fmt.Sprintf("%v", val)))
+			f.SetCellValue(sheet, cell, strings.ToUpper(fmt.Sprintf("%v", val)))
 			if val {
-				f.SetCellStyle(sheet, fmt.Sprintf("%c%d", col, row), fmt.Sprintf("%c%d", col, row), trueStyle)
+				f.SetCellStyle(sheet, cell, cell, trueStyle)
 			} else {
-				f.SetCellStyle(sheet, fmt.Sprintf("%c%d", col, row), fmt.Sprintf("%c%d", col, row), falseStyle)
+				f.SetCellStyle(sheet, cell, cell, falseStyle)
 			}
 			f.SetRowHeight(sheet, row, 30)
 			return nil
 		}
 		if val, exists := branchInfoTyped.ForbiddenFiles[fieldName]; exists {
-			f.SetCellValue(sheet, fmt.Sprintf("%c%d", col, row), strings.ToUpper(fmt.Sprintf("%v", val)))
+			f.SetCellValue(sheet, cell, strings.ToUpper(fmt.Sprintf("%v", val)))
 
 			// For forbidden files, we invert the color logic - true is bad (red), false is good (green)
 			if val {
-				f.SetCellStyle(sheet, fmt.Sprintf("%c%d", col, row), fmt.Sprintf("%c%d", col, row), falseStyle)
+				f.SetCellStyle(sheet, cell, cell, falseStyle)
+			} else {
+				f.SetCellStyle(sheet, cell, cell, trueStyle)
+			}
+			f.SetRowHeight(sheet, row, 30)
+			return nil
+		}
+		if result, exists := branchInfoTyped.RuleResults[fieldName]; exists {
+			f.SetCellValue(sheet, cell, strings.ToUpper(fmt.Sprintf("%v", result.Passed)))
+			if result.Passed {
+				f.SetCellStyle(sheet, cell, cell, trueStyle)
 			} else {
-				f.SetCellStyle(sheet, fmt.Sprintf("%c%d", col, row), fmt.Sprintf("%c%d", col, row), trueStyle)
+				f.SetCellStyle(sheet, cell, cell, falseStyle)
 			}
 			f.SetRowHeight(sheet, row, 30)
 			return nil
@@ -282,14 +418,19 @@ func writeFieldToColumn(f *excelize.File, sheet string, row int, fieldName strin
 		return fmt.Errorf("field %s not found in struct", fieldName)
 	}
 
-	cell := fmt.Sprintf("%c%d", col, row)
 	if fieldName == "LastCommitDate" {
 		f.SetCellValue(sheet, cell, fieldValue.Interface().(time.Time).Format("2006-01-02 15:04"))
 		f.SetCellStyle(sheet, cell, cell, cellStyle)
+	} else if fieldName == "LastReleaseDate" {
+		// Repositories without any tag have no release date
+		if date := fieldValue.Interface().(time.Time); !date.IsZero() {
+			f.SetCellValue(sheet, cell, date.Format("2006-01-02 15:04"))
+		}
+		f.SetCellStyle(sheet, cell, cell, cellStyle)
 	} else if fieldName == "LastDeveloperPercentage" || fieldName == "TopDeveloperPercentage" {
 		f.SetCellValue(sheet, cell, fmt.Sprintf("%.2f%%", fieldValue.Float()))
 		f.SetCellStyle(sheet, cell, cell, cellStyle)
-	} else if fieldName == "Count" || fieldName == "SelectiveCount" {
+	} else if fieldName == "Count" || fieldName == "SelectiveCount" || fieldName == "RulesCount" {
 		// For Count fields, apply conditional formatting based on percentage
 		countStr := fieldValue.String()
 		f.SetCellValue(sheet, cell, countStr)
@@ -317,6 +458,148 @@ func writeFieldToColumn(f *excelize.File, sheet string, row int, fieldName strin
 		} else {
 			f.SetCellStyle(sheet, cell, cell, cellStyle)
 		}
+	} else if fieldName == "ComplianceScore" || fieldName == "ComplianceGrade" {
+		// The score is written as a number so that the column can be sorted and filtered
+		f.SetCellValue(sheet, cell, fieldValue.Interface())
+		if err := setScoreStyle(f, sheet, cell, cell, v.FieldByName("ComplianceScore").Float()); err != nil {
+			return err
+		}
+	} else if fieldName == "BusFactor" {
+		// Knowledge held by one person is red, by two people orange
+		f.SetCellValue(sheet, cell, fieldValue.Int())
+		switch fieldValue.Int() {
+		case 1:
+			f.SetCellStyle(sheet, cell, cell, lowCountStyle)
+		case 2:
+			f.SetCellStyle(sheet, cell, cell, mediumCountStyle)
+		default:
+			f.SetCellStyle(sheet, cell, cell, cellStyle)
+		}
+	} else if fieldName == "FormerEmployeesShare" {
+		// Knowledge mostly held by former employees is red, partly held orange
+		f.SetCellValue(sheet, cell, fmt.Sprintf("%.1f%%", fieldValue.Float()))
+		if fieldValue.Float() >= cfg.App.BusFactorThreshold {
+			f.SetCellStyle(sheet, cell, cell, lowCountStyle)
+		} else if fieldValue.Float() > 0 {
+			f.SetCellStyle(sheet, cell, cell, mediumCountStyle)
+		} else {
+			f.SetCellStyle(sheet, cell, cell, cellStyle)
+		}
+	} else if fieldName == "ConventionalShare" || fieldName == "IssueKeyShare" {
+		// Shares of the commit messages are coloured with the count thresholds, when the branch has commits
+		f.SetCellValue(sheet, cell, fmt.Sprintf("%.1f%%", fieldValue.Float()))
+		if len(v.FieldByName("MessagesByAuthor").MapKeys()) > 0 {
+			if err := setScoreStyle(f, sheet, cell, cell, fieldValue.Float()); err != nil {
+				return err
+			}
+		} else {
+			f.SetCellStyle(sheet, cell, cell, cellStyle)
+		}
+	} else if fieldName == "SignedShare" || fieldName == "VerifiedShare" || fieldName == "UnsignedShare" {
+		// Signed shares are coloured with the count thresholds, unsigned shares inversely.
+		// Without SIGNING_KEYRING nothing is verified.
+		share := fieldValue.Float()
+		if fieldName == "VerifiedShare" && len(cfg.App.SigningKeyring) == 0 {
+			f.SetCellValue(sheet, cell, "N/A")
+			f.SetCellStyle(sheet, cell, cell, cellStyle)
+		} else if len(v.FieldByName("SignaturesByAuthor").MapKeys()) == 0 {
+			f.SetCellValue(sheet, cell, fmt.Sprintf("%.1f%%", share))
+			f.SetCellStyle(sheet, cell, cell, cellStyle)
+		} else {
+			f.SetCellValue(sheet, cell, fmt.Sprintf("%.1f%%", share))
+			if fieldName == "UnsignedShare" {
+				share = 100 - share
+			}
+			if err := setScoreStyle(f, sheet, cell, cell, share); err != nil {
+				return err
+			}
+		}
+	} else if fieldName == "License" {
+		// Repositories without a license are red, and those with an unrecognised license orange
+		f.SetCellValue(sheet, cell, fieldValue.String())
+		switch fieldValue.String() {
+		case "":
+			f.SetCellStyle(sheet, cell, cell, lowCountStyle)
+		case licenses.Unknown:
+			f.SetCellStyle(sheet, cell, cell, mediumCountStyle)
+		default:
+			f.SetCellStyle(sheet, cell, cell, cellStyle)
+		}
+	} else if fieldName == "DocScore" {
+		// Like the compliance score, the documentation score is written as a number
+		f.SetCellValue(sheet, cell, fieldValue.Interface())
+		if err := setScoreStyle(f, sheet, cell, cell, fieldValue.Float()); err != nil {
+			return err
+		}
+	} else if fieldName == "MissingSections" {
+		// Missing README sections are red
+		f.SetCellValue(sheet, cell, fieldValue.String())
+		if fieldValue.String() != "" {
+			f.SetCellStyle(sheet, cell, cell, lowCountStyle)
+		} else {
+			f.SetCellStyle(sheet, cell, cell, cellStyle)
+		}
+	} else if fieldName == "OwnershipCoverage" {
+		// The coverage is coloured with the count thresholds, when the repository has a CODEOWNERS file
+		f.SetCellValue(sheet, cell, fmt.Sprintf("%.1f%%", fieldValue.Float()))
+		if v.FieldByName("HasCodeOwners").Bool() {
+			if err := setScoreStyle(f, sheet, cell, cell, fieldValue.Float()); err != nil {
+				return err
+			}
+		} else {
+			f.SetCellStyle(sheet, cell, cell, lowCountStyle)
+		}
+	} else if fieldName == "TestRatio" {
+		f.SetCellValue(sheet, cell, fmt.Sprintf("%.1f%%", fieldValue.Float()))
+		f.SetCellStyle(sheet, cell, cell, cellStyle)
+	} else if fieldName == "LicenseConfidence" {
+		f.SetCellValue(sheet, cell, fmt.Sprintf("%.0f%%", fieldValue.Float()))
+		f.SetCellStyle(sheet, cell, cell, cellStyle)
+	} else if fieldName == "FilesOverLFS" {
+		// Files that should have been tracked with Git LFS are red
+		f.SetCellValue(sheet, cell, fieldValue.Int())
+		if fieldValue.Int() > 0 {
+			f.SetCellStyle(sheet, cell, cell, lowCountStyle)
+		} else {
+			f.SetCellStyle(sheet, cell, cell, cellStyle)
+		}
+	} else if fieldName == "ActivityTrend" {
+		// Rising activity is green and declining activity red
+		f.SetCellValue(sheet, cell, fieldValue.String())
+		switch fieldValue.String() {
+		case structs.TrendRising:
+			f.SetCellStyle(sheet, cell, cell, highCountStyle)
+		case structs.TrendDeclining:
+			f.SetCellStyle(sheet, cell, cell, lowCountStyle)
+		default:
+			f.SetCellStyle(sheet, cell, cell, cellStyle)
+		}
+	} else if fieldName == "BranchStatus" {
+		// Abandoned branches are red and stale branches orange
+		f.SetCellValue(sheet, cell, fieldValue.String())
+		switch fieldValue.String() {
+		case structs.BranchStatusAbandoned:
+			f.SetCellStyle(sheet, cell, cell, lowCountStyle)
+		case structs.BranchStatusStale:
+			f.SetCellStyle(sheet, cell, cell, mediumCountStyle)
+		default:
+			f.SetCellStyle(sheet, cell, cell, cellStyle)
+		}
+	} else if fieldName == "TipVerified" && len(cfg.App.SigningKeyring) == 0 {
+		f.SetCellValue(sheet, cell, "N/A")
+		f.SetCellStyle(sheet, cell, cell, cellStyle)
+	} else if (complianceFields[fieldName] || fieldName == "TipVerified") && fieldValue.Kind() == reflect.Bool {
+		// Boolean compliance checks use the same TRUE/FALSE styling as the searched files
+		f.SetCellValue(sheet, cell, strings.ToUpper(fmt.Sprintf("%v", fieldValue.Bool())))
+		if fieldValue.Bool() {
+			f.SetCellStyle(sheet, cell, cell, trueStyle)
+		} else {
+			f.SetCellStyle(sheet, cell, cell, falseStyle)
+		}
+	} else if fieldName == "IsDefault" {
+		// Being the default branch is neither good nor bad, so it is not colored
+		f.SetCellValue(sheet, cell, strings.ToUpper(fmt.Sprintf("%v", fieldValue.Bool())))
+		f.SetCellStyle(sheet, cell, cell, cellStyle)
 	} else {
 		f.SetCellValue(sheet, cell, fieldValue.Interface())
 		f.SetCellStyle(sheet, cell, cell, cellStyle)
@@ -365,12 +648,18 @@ func createDeveloperSheets(f *excelize.File, branchesInfo []structs.BranchInfo)
 
 	sortBranchesByLastCommit(branchesInfo)
 
+	// The developer sheets have the columns of the configuration followed by the five columns of churn and messages
+	lastDeveloperColumn, err := excelize.ColumnNumberToName(len(columns) + 5)
+	if err != nil {
+		return err
+	}
+
 	var developers []string
 	for _, branchInfo := range branchesInfo {
 		developers = append(developers, branchInfo.LastDeveloper, branchInfo.TopDeveloper)
 	}
 	for _, developer := range developers {
-		nbrcolumn := 'A'
+		nbrcolumn := 1
 		for _, column := range columns {
 			row := 2
 			for _, branchInfo := range branchesInfo {
@@ -383,9 +672,7 @@ func createDeveloperSheets(f *excelize.File, branchesInfo []structs.BranchInfo)
 					f.NewSheet(developer)
 					developerSheets[developer] = true
 					f.SetRowHeight(developer, 1, 40)
-					for col := 'A'; col < 'Z'; col++ {
-						f.SetColWidth(developer, string(col), string(col), 20)
-					}
+					f.SetColWidth(developer, "A", lastDeveloperColumn, 20)
 				}
 				if strings.ToLower(removeAccentsAndSpecialChars(branchInfo.LastDeveloper)) == developer ||
 					strings.ToLower(removeAccentsAndSpecialChars(branchInfo.TopDeveloper)) == developer {
@@ -401,10 +688,154 @@ func createDeveloperSheets(f *excelize.File, branchesInfo []structs.BranchInfo)
 			nbrcolumn++
 
 		}
+
+		// Lines added and removed by the developer on each of their branches, over the churn window
+		sheetName := strings.ToLower(removeAccentsAndSpecialChars(developer))
+		if developer == "" || !developerSheets[sheetName] {
+			continue
+		}
+		for i, header := range []string{"LINES ADDED", "LINES REMOVED", "CHURN COMMITS", "CONVENTIONAL COMMITS", "ISSUE KEYS"} {
+			styles.SetOneHeader(f, sheetName, header, nbrcolumn+i)
+		}
+		cellStyle, err := styles.CreateCellStyle(f)
+		if err != nil {
+			return err
+		}
+		row := 2
+		for _, branchInfo := range branchesInfo {
+			if strings.ToLower(removeAccentsAndSpecialChars(branchInfo.LastDeveloper)) != sheetName &&
+				strings.ToLower(removeAccentsAndSpecialChars(branchInfo.TopDeveloper)) != sheetName {
+				continue
+			}
+			churn := developerChurn(branchInfo, sheetName)
+			for i, value := range []int{churn.Added, churn.Removed, churn.Commits} {
+				cell, _ := excelize.CoordinatesToCellName(nbrcolumn+i, row)
+				f.SetCellValue(sheetName, cell, value)
+				f.SetCellStyle(sheetName, cell, cell, cellStyle)
+			}
+
+			// Shares of the developer's commit messages following the conventions
+			messages := developerMessages(branchInfo, sheetName)
+			for i, matching := range []int{messages.Conventional, messages.IssueKey} {
+				cell, _ := excelize.CoordinatesToCellName(nbrcolumn+3+i, row)
+				share := commitShare(matching, messages.Commits)
+				f.SetCellValue(sheetName, cell, fmt.Sprintf("%.1f%%", share))
+				f.SetCellStyle(sheetName, cell, cell, cellStyle)
+				if messages.Commits > 0 {
+					if err := setScoreStyle(f, sheetName, cell, cell, share); err != nil {
+						return err
+					}
+				}
+			}
+			row++
+		}
+
+		if err := writeWorstOffenders(f, sheetName, row+2, branchesInfo); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// worstOffendersLimit is the number of branches listed below the table of a developer sheet
+const worstOffendersLimit = 5
+
+// writeWorstOffenders lists, from the given row of a developer sheet, the branches where the
+// commit messages of the developer follow the conventions the least, with an offending subject.
+// Only branches below COUNT_THRESHOLD_MEDIUM on one of the two shares are listed.
+func writeWorstOffenders(f *excelize.File, sheetName string, row int, branchesInfo []structs.BranchInfo) error {
+	cfg := config.Get()
+
+	type offender struct {
+		branch       structs.BranchInfo
+		messages     structs.MessageStats
+		conventional float64
+		issueKey     float64
+	}
+	var offenders []offender
+	for _, branchInfo := range branchesInfo {
+		messages := developerMessages(branchInfo, sheetName)
+		if messages.Commits == 0 {
+			continue
+		}
+		conventional := commitShare(messages.Conventional, messages.Commits)
+		issueKey := commitShare(messages.IssueKey, messages.Commits)
+		if conventional >= float64(cfg.App.CountThresholdMedium) && issueKey >= float64(cfg.App.CountThresholdMedium) {
+			continue
+		}
+		offenders = append(offenders, offender{branchInfo, messages, conventional, issueKey})
+	}
+	if len(offenders) == 0 {
+		return nil
+	}
+	sort.SliceStable(offenders, func(i, j int) bool {
+		return offenders[i].conventional+offenders[i].issueKey < offenders[j].conventional+offenders[j].issueKey
+	})
+	if len(offenders) > worstOffendersLimit {
+		offenders = offenders[:worstOffendersLimit]
+	}
+
+	headerStyle, err := styles.CreateHeaderStyle(f)
+	if err != nil {
+		return err
+	}
+	cellStyle, err := styles.CreateCellStyle(f)
+	if err != nil {
+		return err
+	}
+
+	headers := []string{"WORST OFFENDERS", "BRANCH", "COMMITS", "CONVENTIONAL COMMITS", "ISSUE KEYS", "EXAMPLE"}
+	for i, header := range headers {
+		cell, _ := excelize.CoordinatesToCellName(i+1, row)
+		f.SetCellValue(sheetName, cell, header)
+		f.SetCellStyle(sheetName, cell, cell, headerStyle)
+	}
+	f.SetRowHeight(sheetName, row, 40)
+
+	for _, o := range offenders {
+		row++
+		writeRow(f, sheetName, row, []interface{}{
+			o.branch.RepoName,
+			o.branch.BranchName,
+			o.messages.Commits,
+			fmt.Sprintf("%.1f%%", o.conventional),
+			fmt.Sprintf("%.1f%%", o.issueKey),
+			o.messages.Example,
+		}, cellStyle)
+		if err := setScoreStyle(f, sheetName, fmt.Sprintf("D%d", row), fmt.Sprintf("D%d", row), o.conventional); err != nil {
+			return err
+		}
+		if err := setScoreStyle(f, sheetName, fmt.Sprintf("E%d", row), fmt.Sprintf("E%d", row), o.issueKey); err != nil {
+			return err
+		}
 	}
 	return nil
 }
 
+// developerChurn sums the churn of the authors of a branch whose sheet name is the given one
+func developerChurn(branchInfo structs.BranchInfo, sheetName string) structs.Churn {
+	var total structs.Churn
+	for author, churn := range branchInfo.ChurnByAuthor {
+		if strings.ToLower(removeAccentsAndSpecialChars(author)) == sheetName {
+			total.Commits += churn.Commits
+			total.Added += churn.Added
+			total.Removed += churn.Removed
+		}
+	}
+	return total
+}
+
+// developerMessages sums the commit message statistics of the authors of a branch whose sheet name is the given one
+func developerMessages(branchInfo structs.BranchInfo, sheetName string) structs.MessageStats {
+	var total structs.MessageStats
+	for author, messages := range branchInfo.MessagesByAuthor {
+		if strings.ToLower(removeAccentsAndSpecialChars(author)) == sheetName {
+			total = mergeMessages(total, messages)
+		}
+	}
+	return total
+}
+
 func removeAccentsAndSpecialChars(s string) string {
 	t := norm.NFD.String(s)
 
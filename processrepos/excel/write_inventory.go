@@ -0,0 +1,277 @@
+package excel
+
+import (
+	"fmt"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/s3pweb/gitArchiveS3Report/config"
+	styles "github.com/s3pweb/gitArchiveS3Report/utils/excel"
+	"github.com/s3pweb/gitArchiveS3Report/utils/structs"
+	"github.com/xuri/excelize/v2"
+)
+
+const (
+	inventoryHostname = "Hostname"
+	inventoryPort     = "Port"
+)
+
+// inventoryEntry is a hostname or a published port claimed by a service on one branch.
+// firstPort and lastPort bound a published port or port range, both 0 when it is not a number.
+type inventoryEntry struct {
+	kind           string
+	value          string
+	hostIP         string
+	protocol       string
+	firstPort      int
+	lastPort       int
+	repoName       string
+	branchName     string
+	file           string
+	service        string
+	conflicts      []string
+	nonDefaultOnly bool
+}
+
+// buildInventory lists the hostnames and published ports of every branch of the workspace.
+// A hostname or port is a collision when it is claimed by more than one repository, or a port
+// when two services of the same repository and branch publish it, and a hostname is flagged
+// when none of the default branches of the workspace declares it.
+func buildInventory(branchesInfo []structs.BranchInfo) []*inventoryEntry {
+	defaultBranches := make(map[string]string)
+	for _, branch := range primaryBranches(branchesInfo) {
+		defaultBranches[branch.RepoName] = branch.BranchName
+	}
+
+	var entries []*inventoryEntry
+	for _, branch := range branchesInfo {
+		for _, service := range branch.Services {
+			for _, host := range service.Hosts {
+				entries = append(entries, &inventoryEntry{
+					kind:       inventoryHostname,
+					value:      strings.ToLower(host),
+					repoName:   branch.RepoName,
+					branchName: branch.BranchName,
+					file:       service.File,
+					service:    service.Name,
+				})
+			}
+			for _, port := range service.Ports {
+				if port.Published == "" {
+					continue
+				}
+				protocol := port.Protocol
+				if protocol == "" {
+					protocol = "tcp"
+				}
+				firstPort, lastPort := parsePortRange(port.Published)
+				entries = append(entries, &inventoryEntry{
+					kind:       inventoryPort,
+					value:      port.Published + "/" + protocol,
+					hostIP:     port.HostIP,
+					protocol:   protocol,
+					firstPort:  firstPort,
+					lastPort:   lastPort,
+					repoName:   branch.RepoName,
+					branchName: branch.BranchName,
+					file:       service.File,
+					service:    service.Name,
+				})
+			}
+		}
+	}
+
+	// Hostnames are grouped by name, ports by protocol since a range may overlap other ports
+	groups := make(map[string][]*inventoryEntry)
+	for _, entry := range entries {
+		key := entry.kind + "|" + entry.value
+		if entry.kind == inventoryPort {
+			key = entry.kind + "|" + entry.protocol
+		}
+		groups[key] = append(groups[key], entry)
+	}
+
+	for _, group := range groups {
+		onDefaultBranch := false
+		for _, entry := range group {
+			if defaultBranches[entry.repoName] == entry.branchName {
+				onDefaultBranch = true
+			}
+			for _, other := range group {
+				if !entry.overlaps(other) {
+					continue
+				}
+				conflict, ok := entry.conflictWith(other)
+				if ok && sameHostIP(entry.hostIP, other.hostIP) && !containsString(entry.conflicts, conflict) {
+					entry.conflicts = append(entry.conflicts, conflict)
+				}
+			}
+			sort.Strings(entry.conflicts)
+		}
+		if group[0].kind == inventoryHostname && !onDefaultBranch {
+			for _, entry := range group {
+				entry.nonDefaultOnly = true
+			}
+		}
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		a, b := entries[i], entries[j]
+		if a.kind != b.kind {
+			return a.kind < b.kind
+		}
+		if a.value != b.value {
+			return a.value < b.value
+		}
+		if a.repoName != b.repoName {
+			return a.repoName < b.repoName
+		}
+		return a.branchName < b.branchName
+	})
+	return entries
+}
+
+// overlaps reports whether two entries of a group claim the same hostname, or the same port.
+// Port ranges overlap when they share a port; a port that is not a number, such as a variable,
+// only overlaps the same text.
+func (entry *inventoryEntry) overlaps(other *inventoryEntry) bool {
+	if entry.kind != inventoryPort {
+		return entry.value == other.value
+	}
+	if entry.firstPort == 0 || other.firstPort == 0 {
+		return entry.value == other.value
+	}
+	return entry.firstPort <= other.lastPort && other.firstPort <= entry.lastPort
+}
+
+// conflictWith returns how a collision of the entry with another claim of the same value is
+// listed: the other repository, or the other service when two services of the same repository
+// and branch publish the same port. The same service seen on several branches, or declared in
+// several compose files of a branch (e.g. an override file), is one claim and not a collision.
+// Services of one repository may share a hostname on different paths, so that is not one either.
+func (entry *inventoryEntry) conflictWith(other *inventoryEntry) (string, bool) {
+	if other.repoName != entry.repoName {
+		return other.repoName, true
+	}
+	if entry.kind != inventoryPort || other.branchName != entry.branchName || other.service == entry.service {
+		return "", false
+	}
+	return "service " + other.service, true
+}
+
+// parsePortRange returns the bounds of a published port such as 8080 or a range such as 8000-8010,
+// or zeros when it is not a number
+func parsePortRange(published string) (int, int) {
+	first, last, isRange := strings.Cut(published, "-")
+	firstPort, err := strconv.Atoi(strings.TrimSpace(first))
+	if err != nil || firstPort <= 0 {
+		return 0, 0
+	}
+	if !isRange {
+		return firstPort, firstPort
+	}
+	lastPort, err := strconv.Atoi(strings.TrimSpace(last))
+	if err != nil || lastPort < firstPort {
+		return 0, 0
+	}
+	return firstPort, lastPort
+}
+
+// sameHostIP reports whether two ports bound to these host addresses would clash.
+// An empty address or 0.0.0.0 binds every interface and clashes with any address.
+func sameHostIP(a, b string) bool {
+	if a == "" || a == "0.0.0.0" || b == "" || b == "0.0.0.0" {
+		return true
+	}
+	return a == b
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// writeInventorySheet writes the hostnames and ports of the workspace, highlighting
+// collisions in red and hostnames only found on non-default branches in orange
+func writeInventorySheet(f *excelize.File, branchesInfo []structs.BranchInfo) error {
+	sheet := "Hosts and Ports"
+	f.NewSheet(sheet)
+
+	headers := []string{"TYPE", "VALUE", "HOST IP", "REPOSITORY", "BRANCH", "FILE", "SERVICE", "COLLISION", "CONFLICTS WITH", "NON-DEFAULT BRANCHES ONLY"}
+	for i, header := range headers {
+		col := 'A' + rune(i)
+		styles.SetOneHeader(f, sheet, header, i+1)
+		f.SetColWidth(sheet, string(col), string(col), 25)
+	}
+	f.SetRowHeight(sheet, 1, 40)
+
+	cellStyle, err := styles.CreateCellStyle(f)
+	if err != nil {
+		return err
+	}
+	collisionStyle, err := styles.LowCountStyle(f)
+	if err != nil {
+		return err
+	}
+	nonDefaultStyle, err := styles.MediumCountStyle(f)
+	if err != nil {
+		return err
+	}
+
+	row := 2
+	for _, entry := range buildInventory(branchesInfo) {
+		style := cellStyle
+		if len(entry.conflicts) > 0 {
+			style = collisionStyle
+		} else if entry.nonDefaultOnly {
+			style = nonDefaultStyle
+		}
+
+		writeRow(f, sheet, row, []interface{}{
+			entry.kind,
+			entry.value,
+			entry.hostIP,
+			entry.repoName,
+			entry.branchName,
+			entry.file,
+			entry.service,
+			strings.ToUpper(fmt.Sprintf("%v", len(entry.conflicts) > 0)),
+			strings.Join(entry.conflicts, ", "),
+			strings.ToUpper(fmt.Sprintf("%v", entry.nonDefaultOnly)),
+		}, style)
+
+		if link := bitbucketURL(entry.repoName, "", ""); link != "" {
+			f.SetCellHyperLink(sheet, fmt.Sprintf("D%d", row), link, "External")
+			f.SetCellHyperLink(sheet, fmt.Sprintf("E%d", row), bitbucketURL(entry.repoName, entry.branchName, ""), "External")
+			f.SetCellHyperLink(sheet, fmt.Sprintf("F%d", row), bitbucketURL(entry.repoName, entry.branchName, entry.file), "External")
+		}
+		row++
+	}
+
+	return nil
+}
+
+// bitbucketURL returns the Bitbucket URL of a repository, of one of its branches, or of a
+// file on that branch. It is empty when the Bitbucket workspace is not configured.
+func bitbucketURL(repoName, branchName, file string) string {
+	workspace := config.Get().Bitbucket.Workspace
+	if workspace == "" {
+		return ""
+	}
+
+	link := fmt.Sprintf("https://bitbucket.org/%s/%s", url.PathEscape(workspace), url.PathEscape(repoName))
+	branchName = strings.TrimPrefix(branchName, "origin/")
+	if branchName == "" {
+		return link
+	}
+	if file == "" {
+		return link + "/branch/" + url.PathEscape(branchName)
+	}
+	return link + "/src/" + url.PathEscape(branchName) + "/" + file
+}
@@ -0,0 +1,108 @@
+package excel
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/s3pweb/gitArchiveS3Report/config"
+	styles "github.com/s3pweb/gitArchiveS3Report/utils/excel"
+	"github.com/s3pweb/gitArchiveS3Report/utils/structs"
+	"github.com/xuri/excelize/v2"
+)
+
+// writeKnowledgeSheet writes the bus factor of each repository and the share held by former employees,
+// the most at-risk repositories first. Repositories whose knowledge sits with one person, or mostly
+// with people who have left, are in red, and those with a bus factor of two or some knowledge held
+// by former employees in orange.
+func writeKnowledgeSheet(f *excelize.File, branchesInfo []structs.BranchInfo) error {
+	cfg := config.Get()
+
+	sheet := "Knowledge"
+	f.NewSheet(sheet)
+
+	headers := []string{"REPOSITORY", "BRANCH", "BASIS", "BUS FACTOR", "KEY AUTHORS", "FORMER EMPLOYEES SHARE", "FORMER EMPLOYEES", "RISK"}
+	for i, header := range headers {
+		col := 'A' + rune(i)
+		styles.SetOneHeader(f, sheet, header, i+1)
+		f.SetColWidth(sheet, string(col), string(col), 25)
+	}
+	f.SetColWidth(sheet, "E", "E", 40)
+	f.SetColWidth(sheet, "H", "H", 50)
+	f.SetRowHeight(sheet, 1, 40)
+
+	cellStyle, err := styles.CreateCellStyle(f)
+	if err != nil {
+		return err
+	}
+	highRiskStyle, err := styles.LowCountStyle(f)
+	if err != nil {
+		return err
+	}
+	mediumRiskStyle, err := styles.MediumCountStyle(f)
+	if err != nil {
+		return err
+	}
+
+	repos := primaryBranches(branchesInfo)
+	sort.SliceStable(repos, func(i, j int) bool {
+		if repos[i].FormerEmployeesShare != repos[j].FormerEmployeesShare {
+			return repos[i].FormerEmployeesShare > repos[j].FormerEmployeesShare
+		}
+		return repos[i].BusFactor < repos[j].BusFactor
+	})
+
+	row := 2
+	for _, repo := range repos {
+		risks := knowledgeRisks(repo.Knowledge, cfg.App.BusFactorThreshold)
+
+		style := cellStyle
+		switch knowledgeRiskLevel(repo.Knowledge, cfg.App.BusFactorThreshold) {
+		case 2:
+			style = highRiskStyle
+		case 1:
+			style = mediumRiskStyle
+		}
+
+		writeRow(f, sheet, row, []interface{}{
+			repo.RepoName,
+			repo.BranchName,
+			repo.Knowledge.Basis,
+			repo.BusFactor,
+			repo.BusFactorAuthors,
+			fmt.Sprintf("%.1f%%", repo.FormerEmployeesShare),
+			repo.FormerEmployees,
+			strings.Join(risks, ", "),
+		}, style)
+		row++
+	}
+
+	return nil
+}
+
+// knowledgeRiskLevel returns 2 when the knowledge of a repository sits with one person or mostly with
+// former employees, 1 when it sits with two people or partly with former employees, and 0 otherwise
+func knowledgeRiskLevel(knowledge structs.KnowledgeInfo, threshold float64) int {
+	switch {
+	case knowledge.BusFactor == 1 || knowledge.FormerShare >= threshold:
+		return 2
+	case knowledge.BusFactor == 2 || knowledge.FormerShare > 0:
+		return 1
+	}
+	return 0
+}
+
+// knowledgeRisks describes why the knowledge of a repository is at risk
+func knowledgeRisks(knowledge structs.KnowledgeInfo, threshold float64) []string {
+	var risks []string
+	switch knowledge.BusFactor {
+	case 1:
+		risks = append(risks, fmt.Sprintf("one person holds %.0f%% of the %s", threshold, knowledge.Basis))
+	case 2:
+		risks = append(risks, fmt.Sprintf("two people hold %.0f%% of the %s", threshold, knowledge.Basis))
+	}
+	if knowledge.FormerShare > 0 {
+		risks = append(risks, fmt.Sprintf("former employees hold %.1f%% of the %s", knowledge.FormerShare, knowledge.Basis))
+	}
+	return risks
+}
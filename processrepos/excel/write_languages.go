@@ -0,0 +1,98 @@
+package excel
+
+import (
+	"fmt"
+	"sort"
+
+	styles "github.com/s3pweb/gitArchiveS3Report/utils/excel"
+	"github.com/s3pweb/gitArchiveS3Report/utils/structs"
+	"github.com/xuri/excelize/v2"
+)
+
+// languageTotals holds the workspace-wide line counts of one language
+type languageTotals struct {
+	name         string
+	repositories int
+	stats        structs.LanguageStats
+}
+
+// writeLanguagesSheet writes the line counts per language aggregated across the workspace.
+// Each repository is counted once, using its main branch when it has one.
+func writeLanguagesSheet(f *excelize.File, branchesInfo []structs.BranchInfo) error {
+	sheet := "Languages"
+	f.NewSheet(sheet)
+
+	totalsByName := make(map[string]*languageTotals)
+	for _, branch := range primaryBranches(branchesInfo) {
+		for name, stats := range branch.Languages {
+			totals, exists := totalsByName[name]
+			if !exists {
+				totals = &languageTotals{name: name}
+				totalsByName[name] = totals
+			}
+			totals.repositories++
+			totals.stats = totals.stats.Add(stats)
+		}
+	}
+
+	var totals []*languageTotals
+	var workspace structs.LanguageStats
+	for _, language := range totalsByName {
+		totals = append(totals, language)
+		workspace = workspace.Add(language.stats)
+	}
+	sort.Slice(totals, func(i, j int) bool {
+		if totals[i].stats.Code == totals[j].stats.Code {
+			return totals[i].name < totals[j].name
+		}
+		return totals[i].stats.Code > totals[j].stats.Code
+	})
+
+	headers := []string{"LANGUAGE", "REPOSITORIES", "FILES", "CODE", "COMMENT", "BLANK", "SHARE OF CODE"}
+	for i, header := range headers {
+		col := 'A' + rune(i)
+		styles.SetOneHeader(f, sheet, header, i+1)
+		f.SetColWidth(sheet, string(col), string(col), 20)
+	}
+	f.SetRowHeight(sheet, 1, 40)
+
+	cellStyle, err := styles.CreateCellStyle(f)
+	if err != nil {
+		return err
+	}
+
+	row := 2
+	for _, language := range totals {
+		share := 0.0
+		if workspace.Code > 0 {
+			share = float64(language.stats.Code) / float64(workspace.Code) * 100
+		}
+		values := []interface{}{
+			language.name,
+			language.repositories,
+			language.stats.Files,
+			language.stats.Code,
+			language.stats.Comment,
+			language.stats.Blank,
+			fmt.Sprintf("%.1f%%", share),
+		}
+		writeRow(f, sheet, row, values, cellStyle)
+		row++
+	}
+
+	writeRow(f, sheet, row, []interface{}{
+		"TOTAL", "", workspace.Files, workspace.Code, workspace.Comment, workspace.Blank, "",
+	}, cellStyle)
+
+	return nil
+}
+
+// writeRow writes the values into consecutive cells of a row, starting at column A
+func writeRow(f *excelize.File, sheet string, row int, values []interface{}, style int) {
+	for i, value := range values {
+		cell, _ := excelize.CoordinatesToCellName(i+1, row)
+		f.SetCellValue(sheet, cell, value)
+		f.SetCellStyle(sheet, cell, cell, style)
+	}
+	f.SetRowHeight(sheet, row, 30)
+}
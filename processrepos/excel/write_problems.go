@@ -0,0 +1,91 @@
+package excel
+
+import (
+	"sort"
+
+	styles "github.com/s3pweb/gitArchiveS3Report/utils/excel"
+	"github.com/s3pweb/gitArchiveS3Report/utils/structs"
+	"github.com/xuri/excelize/v2"
+)
+
+// problemStageOrder sorts the problems from the failures to the expected omissions
+var problemStageOrder = map[string]int{
+	structs.ProblemStageOpen:      0,
+	structs.ProblemStageCheckout:  1,
+	structs.ProblemStageAnalysis:  2,
+	structs.ProblemStageTimeout:   3,
+	structs.ProblemStageCancelled: 4,
+	structs.ProblemStageEmpty:     5,
+	structs.ProblemStageFiltered:  6,
+}
+
+// writeProblemsSheet writes the folders of the workspace that are missing from the report, with the stage
+// at which they were left out and the error, followed by the count of the folders of the workspace, so that
+// the report accounts for all of them. Failures and timeouts are in red; empty, filtered out and
+// cancelled repositories in orange. Folders that are neither analyzed nor listed are counted in red.
+func writeProblemsSheet(f *excelize.File, branchesInfo []structs.BranchInfo, problems []structs.RepoProblem, folders int) error {
+	sheet := "Problems"
+	f.NewSheet(sheet)
+
+	headers := []string{"REPOSITORY", "STAGE", "ERROR"}
+	for i, header := range headers {
+		col := 'A' + rune(i)
+		styles.SetOneHeader(f, sheet, header, i+1)
+		f.SetColWidth(sheet, string(col), string(col), 25)
+	}
+	f.SetColWidth(sheet, "C", "C", 100)
+	f.SetRowHeight(sheet, 1, 40)
+
+	failedStyle, err := styles.LowCountStyle(f)
+	if err != nil {
+		return err
+	}
+	skippedStyle, err := styles.MediumCountStyle(f)
+	if err != nil {
+		return err
+	}
+	cellStyle, err := styles.CreateCellStyle(f)
+	if err != nil {
+		return err
+	}
+
+	sorted := append([]structs.RepoProblem(nil), problems...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return problemStageOrder[sorted[i].Stage] < problemStageOrder[sorted[j].Stage]
+	})
+
+	row := 2
+	for _, problem := range sorted {
+		style := failedStyle
+		switch problem.Stage {
+		case structs.ProblemStageEmpty, structs.ProblemStageFiltered, structs.ProblemStageCancelled:
+			style = skippedStyle
+		}
+		writeRow(f, sheet, row, []interface{}{problem.RepoName, problem.Stage, problem.Error}, style)
+		f.SetRowHeight(sheet, row, 30)
+		row++
+	}
+
+	// Every folder of the workspace should be either in the report or in the table above
+	analyzed := countUniqueRepos(branchesInfo)
+	unaccounted := folders - analyzed - len(problems)
+	row++
+	for _, total := range []struct {
+		label string
+		count int
+	}{
+		{"FOLDERS IN THE WORKSPACE", folders},
+		{"ANALYZED REPOSITORIES", analyzed},
+		{"REPOSITORIES WITH PROBLEMS", len(problems)},
+		{"UNACCOUNTED FOLDERS", unaccounted},
+	} {
+		style := cellStyle
+		if total.label == "UNACCOUNTED FOLDERS" && unaccounted != 0 {
+			style = failedStyle
+		}
+		writeRow(f, sheet, row, []interface{}{total.label, total.count}, style)
+		f.SetRowHeight(sheet, row, 30)
+		row++
+	}
+	return nil
+}
@@ -0,0 +1,85 @@
+package excel
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/s3pweb/gitArchiveS3Report/config"
+	styles "github.com/s3pweb/gitArchiveS3Report/utils/excel"
+	"github.com/s3pweb/gitArchiveS3Report/utils/structs"
+	"github.com/xuri/excelize/v2"
+)
+
+// writeReleasesSheet writes the latest release of each repository and the work not released yet,
+// the repositories with the most unreleased commits first. Repositories with at least
+// RELEASE_PENDING_COMMITS unreleased commits are in orange, and diverged develop branches in red.
+func writeReleasesSheet(f *excelize.File, branchesInfo []structs.BranchInfo) error {
+	cfg := config.Get()
+
+	sheet := "Releases"
+	f.NewSheet(sheet)
+
+	headers := []string{"REPOSITORY", "DEFAULT BRANCH", "LATEST TAG", "SEMVER", "TAGS", "LAST RELEASE", "DAYS SINCE RELEASE", "COMMITS SINCE TAG", "DEVELOP AHEAD", "DEVELOP BEHIND", "DEVELOP DIVERGED"}
+	for i, header := range headers {
+		col := 'A' + rune(i)
+		styles.SetOneHeader(f, sheet, header, i+1)
+		f.SetColWidth(sheet, string(col), string(col), 25)
+	}
+	f.SetRowHeight(sheet, 1, 40)
+
+	cellStyle, err := styles.CreateCellStyle(f)
+	if err != nil {
+		return err
+	}
+	pendingStyle, err := styles.MediumCountStyle(f)
+	if err != nil {
+		return err
+	}
+	divergedStyle, err := styles.LowCountStyle(f)
+	if err != nil {
+		return err
+	}
+
+	repos := primaryBranches(branchesInfo)
+	sort.SliceStable(repos, func(i, j int) bool {
+		return repos[i].CommitsSinceTag > repos[j].CommitsSinceTag
+	})
+
+	row := 2
+	for _, repo := range repos {
+		lastRelease := ""
+		daysSinceRelease := ""
+		if !repo.LastReleaseDate.IsZero() {
+			lastRelease = repo.LastReleaseDate.Format("2006-01-02 15:04")
+			daysSinceRelease = fmt.Sprintf("%d", repo.DaysSinceRelease)
+		}
+
+		style := cellStyle
+		if repo.CommitsSinceTag >= cfg.App.ReleasePendingCommits {
+			style = pendingStyle
+		}
+
+		writeRow(f, sheet, row, []interface{}{
+			repo.RepoName,
+			repo.DefaultBranch,
+			repo.LatestTag,
+			strings.ToUpper(fmt.Sprintf("%v", repo.LatestTagSemver)),
+			repo.TagCount,
+			lastRelease,
+			daysSinceRelease,
+			repo.CommitsSinceTag,
+			repo.DevelopAhead,
+			repo.DevelopBehind,
+			strings.ToUpper(fmt.Sprintf("%v", repo.DevelopDiverged)),
+		}, style)
+
+		if repo.DevelopDiverged {
+			cell := fmt.Sprintf("K%d", row)
+			f.SetCellStyle(sheet, cell, cell, divergedStyle)
+		}
+		row++
+	}
+
+	return nil
+}
@@ -0,0 +1,54 @@
+package excel
+
+import (
+	"strings"
+
+	styles "github.com/s3pweb/gitArchiveS3Report/utils/excel"
+	"github.com/s3pweb/gitArchiveS3Report/utils/structs"
+	"github.com/xuri/excelize/v2"
+)
+
+// writeServicesSheet writes one row per docker-compose service of the main branch of each repository
+func writeServicesSheet(f *excelize.File, branchesInfo []structs.BranchInfo) error {
+	sheet := "Services"
+	f.NewSheet(sheet)
+
+	headers := []string{"REPOSITORY", "BRANCH", "FILE", "SERVICE", "IMAGE", "TAG", "PORTS", "NETWORKS", "TRAEFIK RULES", "HOSTS"}
+	for i, header := range headers {
+		col := 'A' + rune(i)
+		styles.SetOneHeader(f, sheet, header, i+1)
+		f.SetColWidth(sheet, string(col), string(col), 25)
+	}
+	f.SetRowHeight(sheet, 1, 40)
+
+	cellStyle, err := styles.CreateCellStyle(f)
+	if err != nil {
+		return err
+	}
+
+	row := 2
+	for _, branch := range primaryBranches(branchesInfo) {
+		for _, service := range branch.Services {
+			var ports []string
+			for _, port := range service.Ports {
+				ports = append(ports, port.String())
+			}
+
+			writeRow(f, sheet, row, []interface{}{
+				branch.RepoName,
+				branch.BranchName,
+				service.File,
+				service.Name,
+				service.Image,
+				service.Tag,
+				strings.Join(ports, ", "),
+				strings.Join(service.Networks, ", "),
+				strings.Join(service.Rules, ", "),
+				strings.Join(service.Hosts, ", "),
+			}, cellStyle)
+			row++
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,150 @@
+package excel
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/s3pweb/gitArchiveS3Report/config"
+	styles "github.com/s3pweb/gitArchiveS3Report/utils/excel"
+	"github.com/s3pweb/gitArchiveS3Report/utils/structs"
+	"github.com/xuri/excelize/v2"
+)
+
+// writeSignaturesSheet writes the shares of signed, verified and unsigned commits of the main branch
+// of each repository, the least signed first, with the branches whose last commit is unsigned,
+// followed by the same shares per developer. The shares are coloured with the count thresholds,
+// and the verified shares are only written when SIGNING_KEYRING is set.
+func writeSignaturesSheet(f *excelize.File, branchesInfo []structs.BranchInfo) error {
+	cfg := config.Get()
+	keyring := len(cfg.App.SigningKeyring) > 0
+
+	sheet := "Signatures"
+	f.NewSheet(sheet)
+
+	headers := []string{"REPOSITORY", "BRANCH", "COMMITS", "SIGNED", "VERIFIED", "UNSIGNED", "TIP SIGNED", "UNSIGNED TIPS"}
+	for i, header := range headers {
+		col := 'A' + rune(i)
+		styles.SetOneHeader(f, sheet, header, i+1)
+		f.SetColWidth(sheet, string(col), string(col), 25)
+	}
+	f.SetColWidth(sheet, "H", "H", 60)
+	f.SetRowHeight(sheet, 1, 40)
+
+	headerStyle, err := styles.CreateHeaderStyle(f)
+	if err != nil {
+		return err
+	}
+	cellStyle, err := styles.CreateCellStyle(f)
+	if err != nil {
+		return err
+	}
+	trueStyle, err := styles.TrueCells(f)
+	if err != nil {
+		return err
+	}
+	falseStyle, err := styles.FalseCells(f)
+	if err != nil {
+		return err
+	}
+
+	// Branches whose last commit is unsigned, per repository
+	unsignedTips := make(map[string][]string)
+	for _, branch := range branchesInfo {
+		if !branch.TipSigned {
+			unsignedTips[branch.RepoName] = append(unsignedTips[branch.RepoName], branch.BranchName)
+		}
+	}
+
+	repos := primaryBranches(branchesInfo)
+	sort.SliceStable(repos, func(i, j int) bool {
+		return repos[i].SignedShare < repos[j].SignedShare
+	})
+
+	// The developers are counted on the main branches, so that shared history is counted once
+	developers := make(map[string]structs.SignatureStats)
+
+	row := 2
+	for _, repo := range repos {
+		var total structs.SignatureStats
+		for author, signatures := range repo.SignaturesByAuthor {
+			total = mergeSignatures(total, signatures)
+			developers[author] = mergeSignatures(developers[author], signatures)
+		}
+
+		if err := writeSignaturesRow(f, sheet, row, []interface{}{repo.RepoName, repo.BranchName}, total, keyring, cellStyle); err != nil {
+			return err
+		}
+		tipCell := fmt.Sprintf("G%d", row)
+		f.SetCellValue(sheet, tipCell, strings.ToUpper(fmt.Sprintf("%v", repo.TipSigned)))
+		if repo.TipSigned {
+			f.SetCellStyle(sheet, tipCell, tipCell, trueStyle)
+		} else {
+			f.SetCellStyle(sheet, tipCell, tipCell, falseStyle)
+		}
+		tipsCell := fmt.Sprintf("H%d", row)
+		f.SetCellValue(sheet, tipsCell, strings.Join(unsignedTips[repo.RepoName], ", "))
+		f.SetCellStyle(sheet, tipsCell, tipsCell, cellStyle)
+		row++
+	}
+
+	// Developers, the least signed first
+	row += 2
+	for i, header := range []string{"DEVELOPER", "", "COMMITS", "SIGNED", "VERIFIED", "UNSIGNED"} {
+		cell, _ := excelize.CoordinatesToCellName(i+1, row)
+		f.SetCellValue(sheet, cell, header)
+		f.SetCellStyle(sheet, cell, cell, headerStyle)
+	}
+	f.SetRowHeight(sheet, row, 40)
+
+	var names []string
+	for name := range developers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	sort.SliceStable(names, func(i, j int) bool {
+		a, b := developers[names[i]], developers[names[j]]
+		return commitShare(a.Signed, a.Commits) < commitShare(b.Signed, b.Commits)
+	})
+	for _, name := range names {
+		row++
+		if err := writeSignaturesRow(f, sheet, row, []interface{}{name, ""}, developers[name], keyring, cellStyle); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// writeSignaturesRow writes the two first values of a row followed by the commit count and the
+// signed, verified and unsigned shares, coloured with the count thresholds
+func writeSignaturesRow(f *excelize.File, sheet string, row int, values []interface{}, stats structs.SignatureStats, keyring bool, cellStyle int) error {
+	signed := commitShare(stats.Signed, stats.Commits)
+	verified := commitShare(stats.Verified, stats.Commits)
+	unsigned := commitShare(stats.Commits-stats.Signed, stats.Commits)
+
+	verifiedValue := "N/A"
+	if keyring {
+		verifiedValue = fmt.Sprintf("%.1f%%", verified)
+	}
+	writeRow(f, sheet, row, append(values,
+		stats.Commits,
+		fmt.Sprintf("%.1f%%", signed),
+		verifiedValue,
+		fmt.Sprintf("%.1f%%", unsigned),
+	), cellStyle)
+	f.SetRowHeight(sheet, row, 30)
+
+	if stats.Commits == 0 {
+		return nil
+	}
+	if err := setScoreStyle(f, sheet, fmt.Sprintf("D%d", row), fmt.Sprintf("D%d", row), signed); err != nil {
+		return err
+	}
+	if keyring {
+		if err := setScoreStyle(f, sheet, fmt.Sprintf("E%d", row), fmt.Sprintf("E%d", row), verified); err != nil {
+			return err
+		}
+	}
+	return setScoreStyle(f, sheet, fmt.Sprintf("F%d", row), fmt.Sprintf("F%d", row), 100-unsigned)
+}
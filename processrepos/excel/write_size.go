@@ -0,0 +1,109 @@
+package excel
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/s3pweb/gitArchiveS3Report/config"
+	styles "github.com/s3pweb/gitArchiveS3Report/utils/excel"
+	"github.com/s3pweb/gitArchiveS3Report/utils/structs"
+	"github.com/xuri/excelize/v2"
+)
+
+// writeSizeSheet writes the size of each repository, the largest first, followed by the largest files
+// of each repository in the tree of the default branch and in its history. Repositories and files
+// over LFS_THRESHOLD_MB, which should have been tracked with Git LFS, are in red.
+func writeSizeSheet(f *excelize.File, branchesInfo []structs.BranchInfo) error {
+	cfg := config.Get()
+
+	sheet := "Size"
+	f.NewSheet(sheet)
+
+	headers := []string{"REPOSITORY", "SIZE ON DISK (MB)", "PACK SIZE (MB)", "OBJECTS", "FILES OVER LFS THRESHOLD", "LARGEST FILE"}
+	for i, header := range headers {
+		col := 'A' + rune(i)
+		styles.SetOneHeader(f, sheet, header, i+1)
+		f.SetColWidth(sheet, string(col), string(col), 25)
+	}
+	f.SetColWidth(sheet, "B", "B", 50)
+	f.SetColWidth(sheet, "F", "F", 60)
+	f.SetRowHeight(sheet, 1, 40)
+
+	headerStyle, err := styles.CreateHeaderStyle(f)
+	if err != nil {
+		return err
+	}
+	cellStyle, err := styles.CreateCellStyle(f)
+	if err != nil {
+		return err
+	}
+	lfsStyle, err := styles.LowCountStyle(f)
+	if err != nil {
+		return err
+	}
+
+	repos := primaryBranches(branchesInfo)
+	sort.SliceStable(repos, func(i, j int) bool {
+		return repos[i].RepoSizeMB > repos[j].RepoSizeMB
+	})
+
+	row := 2
+	for _, repo := range repos {
+		style := cellStyle
+		if repo.FilesOverLFS > 0 {
+			style = lfsStyle
+		}
+		writeRow(f, sheet, row, []interface{}{
+			repo.RepoName,
+			repo.RepoSizeMB,
+			repo.PackSizeMB,
+			repo.ObjectCount,
+			repo.FilesOverLFS,
+			repo.LargestFile,
+		}, style)
+		f.SetRowHeight(sheet, row, 30)
+		row++
+	}
+
+	// Largest files of each repository, in the same order
+	row += 2
+	fileHeaders := []string{"REPOSITORY", "PATH", "SIZE (MB)", "IN DEFAULT BRANCH", "INTRODUCED BY", "DATE", "SHOULD USE LFS"}
+	for i, header := range fileHeaders {
+		cell, _ := excelize.CoordinatesToCellName(i+1, row)
+		f.SetCellValue(sheet, cell, header)
+		f.SetCellStyle(sheet, cell, cell, headerStyle)
+	}
+	f.SetColWidth(sheet, "G", "G", 25)
+	f.SetRowHeight(sheet, row, 40)
+
+	for _, repo := range repos {
+		for _, file := range repo.Size.LargeFiles {
+			row++
+			date := ""
+			if !file.Date.IsZero() {
+				date = file.Date.Format("2006-01-02 15:04")
+			}
+			style := cellStyle
+			if file.OverLFS {
+				style = lfsStyle
+			}
+			writeRow(f, sheet, row, []interface{}{
+				repo.RepoName,
+				file.Path,
+				file.SizeMB,
+				strings.ToUpper(fmt.Sprintf("%v", file.InTree)),
+				file.Commit,
+				date,
+				strings.ToUpper(fmt.Sprintf("%v", file.OverLFS)),
+			}, style)
+			f.SetRowHeight(sheet, row, 30)
+		}
+	}
+
+	// The threshold is recalled below the tables
+	row += 2
+	cell := fmt.Sprintf("A%d", row)
+	f.SetCellValue(sheet, cell, fmt.Sprintf("LFS threshold: %.2f MB", cfg.App.LFSThresholdMB))
+	return nil
+}
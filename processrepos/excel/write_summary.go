@@ -0,0 +1,103 @@
+package excel
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/s3pweb/gitArchiveS3Report/config"
+	styles "github.com/s3pweb/gitArchiveS3Report/utils/excel"
+	"github.com/s3pweb/gitArchiveS3Report/utils/rules"
+	"github.com/s3pweb/gitArchiveS3Report/utils/structs"
+	"github.com/xuri/excelize/v2"
+)
+
+// writeSummarySheet writes the compliance score of each repository, which is the score of its main branch,
+// with the average and lowest scores of its branches, followed by the workspace score:
+// the average of the repository scores
+func writeSummarySheet(f *excelize.File, branchesInfo []structs.BranchInfo) error {
+	sheet := "Summary"
+	f.NewSheet(sheet)
+
+	headers := []string{"REPOSITORY", "BRANCH", "SCORE", "GRADE", "BRANCHES", "AVERAGE BRANCH SCORE", "LOWEST BRANCH SCORE"}
+	for i, header := range headers {
+		col := 'A' + rune(i)
+		styles.SetOneHeader(f, sheet, header, i+1)
+		f.SetColWidth(sheet, string(col), string(col), 25)
+	}
+	f.SetRowHeight(sheet, 1, 40)
+
+	cellStyle, err := styles.CreateCellStyle(f)
+	if err != nil {
+		return err
+	}
+
+	type branchScores struct {
+		count  int
+		total  float64
+		lowest float64
+	}
+	scores := make(map[string]*branchScores)
+	for _, branch := range branchesInfo {
+		repoScores, exists := scores[branch.RepoName]
+		if !exists {
+			repoScores = &branchScores{lowest: branch.ComplianceScore}
+			scores[branch.RepoName] = repoScores
+		}
+		repoScores.count++
+		repoScores.total += branch.ComplianceScore
+		repoScores.lowest = math.Min(repoScores.lowest, branch.ComplianceScore)
+	}
+
+	row := 2
+	workspaceTotal := 0.0
+	primaries := primaryBranches(branchesInfo)
+	for _, branch := range primaries {
+		repoScores := scores[branch.RepoName]
+		average := math.Round(repoScores.total/float64(repoScores.count)*10) / 10
+
+		writeRow(f, sheet, row, []interface{}{
+			branch.RepoName,
+			branch.BranchName,
+			branch.ComplianceScore,
+			branch.ComplianceGrade,
+			repoScores.count,
+			average,
+			repoScores.lowest,
+		}, cellStyle)
+		if err := setScoreStyle(f, sheet, fmt.Sprintf("C%d", row), fmt.Sprintf("D%d", row), branch.ComplianceScore); err != nil {
+			return err
+		}
+
+		workspaceTotal += branch.ComplianceScore
+		row++
+	}
+
+	if len(primaries) == 0 {
+		return nil
+	}
+
+	workspaceScore := math.Round(workspaceTotal/float64(len(primaries))*10) / 10
+	writeRow(f, sheet, row, []interface{}{"WORKSPACE", "", workspaceScore, rules.Grade(workspaceScore), len(branchesInfo), "", ""}, cellStyle)
+	f.SetRowHeight(sheet, row, 30)
+	return setScoreStyle(f, sheet, fmt.Sprintf("C%d", row), fmt.Sprintf("D%d", row), workspaceScore)
+}
+
+// setScoreStyle colours the cells of a compliance score with the count thresholds:
+// red below COUNT_THRESHOLD_LOW, orange below COUNT_THRESHOLD_MEDIUM and green above
+func setScoreStyle(f *excelize.File, sheet, firstCell, lastCell string, score float64) error {
+	cfg := config.Get()
+
+	var style int
+	var err error
+	if score < float64(cfg.App.CountThresholdLow) {
+		style, err = styles.LowCountStyle(f)
+	} else if score < float64(cfg.App.CountThresholdMedium) {
+		style, err = styles.MediumCountStyle(f)
+	} else {
+		style, err = styles.HighCountStyle(f)
+	}
+	if err != nil {
+		return err
+	}
+	return f.SetCellStyle(sheet, firstCell, lastCell, style)
+}
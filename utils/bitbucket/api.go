@@ -0,0 +1,76 @@
+package bitbucket
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/s3pweb/gitArchiveS3Report/config"
+)
+
+// apiBaseURL is the base URL of the Bitbucket Cloud API
+const apiBaseURL = "https://api.bitbucket.org/2.0"
+
+// Enabled reports whether the Bitbucket API can be called: the workspace and the token are set
+func Enabled() bool {
+	cfg := config.Get()
+	return cfg.Bitbucket.Workspace != "" && cfg.Bitbucket.Token != ""
+}
+
+// MainBranch returns the main branch of a repository of the workspace, as set in Bitbucket.
+// The request is abandoned when the context is cancelled.
+func MainBranch(ctx context.Context, repoSlug string) (string, error) {
+	cfg := config.Get()
+
+	// Build the API URL
+	apiURL := fmt.Sprintf("%s/repositories/%s/%s?fields=mainbranch.name",
+		apiBaseURL,
+		url.PathEscape(cfg.Bitbucket.Workspace),
+		url.PathEscape(repoSlug))
+
+	// Create the request
+	client := &http.Client{Timeout: time.Second * 10}
+	req, err := http.NewRequestWithContext(ctx, "GET", apiURL, nil)
+	if err != nil {
+		return "", err
+	}
+
+	// Add authentication: an app password with the username, or an access token alone
+	if cfg.Bitbucket.Username != "" {
+		req.SetBasicAuth(cfg.Bitbucket.Username, cfg.Bitbucket.Token)
+	} else {
+		req.Header.Set("Authorization", "Bearer "+cfg.Bitbucket.Token)
+	}
+	req.Header.Set("Accept", "application/json")
+
+	// Execute the request
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", err
+	}
+
+	// Check the response status
+	if resp.StatusCode != 200 {
+		return "", fmt.Errorf("error while reading repository %s: %s", repoSlug, resp.Status)
+	}
+
+	var repository struct {
+		MainBranch struct {
+			Name string `json:"name"`
+		} `json:"mainbranch"`
+	}
+	if err := json.Unmarshal(body, &repository); err != nil {
+		return "", err
+	}
+	return repository.MainBranch.Name, nil
+}
@@ -0,0 +1,173 @@
+package codeowners
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/go-git/go-git/v5/plumbing/format/gitignore"
+	"github.com/s3pweb/gitArchiveS3Report/utils/structs"
+)
+
+// Locations are the paths where a CODEOWNERS file is looked for, in order
+var Locations = []string{"CODEOWNERS", ".bitbucket/CODEOWNERS", "docs/CODEOWNERS"}
+
+var (
+	// ownerRegex matches the owners of a rule: @user, @org/team, @@group or an email address
+	ownerRegex = regexp.MustCompile(`^(@{1,2}[\w.\-]+(/[\w.\-]+)?|[^@\s]+@[^@\s]+\.[^@\s]+)$`)
+
+	// groupRegex matches the group definitions of Bitbucket, e.g. "@@@Backend @alice @bob"
+	groupRegex = regexp.MustCompile(`^@@@\S+`)
+)
+
+// rule is a pattern of a CODEOWNERS file and its owners. A rule without owners leaves
+// the files it matches without owner.
+type rule struct {
+	pattern gitignore.Pattern
+	owners  []string
+}
+
+// Analyze reads the CODEOWNERS file of a repository and computes the share of the files
+// covered by at least one owner. Like in .gitignore, the last rule matching a file wins.
+// Lines that cannot be parsed are reported and ignored.
+func Analyze(repoPath string, files []string) structs.CodeOwnersInfo {
+	var info structs.CodeOwnersInfo
+
+	for _, location := range Locations {
+		if stat, err := os.Stat(filepath.Join(repoPath, location)); err == nil && stat.Mode().IsRegular() {
+			info.File = location
+			break
+		}
+	}
+	if info.File == "" {
+		return info
+	}
+	info.Present = true
+
+	rules, errors, err := parse(filepath.Join(repoPath, info.File))
+	if err != nil {
+		info.ParseErrors = []string{err.Error()}
+		return info
+	}
+	info.ParseErrors = errors
+	info.Valid = len(errors) == 0
+	info.Rules = len(rules)
+
+	// Files per owner, to list the owners covering the most files first. Owners
+	// whose rules match no file are still listed, last.
+	ownerFiles := make(map[string]int)
+	for _, rule := range rules {
+		for _, owner := range rule.owners {
+			if _, listed := ownerFiles[owner]; !listed {
+				ownerFiles[owner] = 0
+			}
+		}
+	}
+
+	info.Files = len(files)
+	for _, file := range files {
+		path := strings.Split(file, "/")
+		for i := len(rules) - 1; i >= 0; i-- {
+			if rules[i].pattern.Match(path, false) != gitignore.Exclude {
+				continue
+			}
+			if len(rules[i].owners) > 0 {
+				info.Covered++
+			}
+			for _, owner := range rules[i].owners {
+				ownerFiles[owner]++
+			}
+			break
+		}
+	}
+	if info.Files > 0 {
+		info.Coverage = float64(info.Covered) / float64(info.Files) * 100
+	}
+
+	for owner := range ownerFiles {
+		info.Owners = append(info.Owners, owner)
+	}
+	sort.Slice(info.Owners, func(i, j int) bool {
+		a, b := info.Owners[i], info.Owners[j]
+		if ownerFiles[a] != ownerFiles[b] {
+			return ownerFiles[a] > ownerFiles[b]
+		}
+		return a < b
+	})
+	return info
+}
+
+// parse reads the rules of a CODEOWNERS file, and describes the lines that cannot be parsed
+func parse(path string) ([]rule, []string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer file.Close()
+
+	var rules []rule
+	var errors []string
+	lineNumber := 0
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		lineNumber++
+		line := strings.TrimSpace(stripComment(scanner.Text()))
+		if line == "" || groupRegex.MatchString(line) || strings.HasPrefix(line, "Check(") {
+			continue
+		}
+
+		fields := strings.Fields(line)
+		pattern := strings.ReplaceAll(fields[0], `\#`, "#")
+		if strings.HasPrefix(pattern, "!") {
+			errors = append(errors, fmt.Sprintf("line %d: negated pattern %s is not supported", lineNumber, pattern))
+			continue
+		}
+
+		var owners []string
+		valid := true
+		for _, owner := range fields[1:] {
+			if !ownerRegex.MatchString(owner) {
+				errors = append(errors, fmt.Sprintf("line %d: invalid owner %s", lineNumber, owner))
+				valid = false
+				break
+			}
+			owners = append(owners, owner)
+		}
+		if valid {
+			rules = append(rules, rule{pattern: gitignore.ParsePattern(pattern, nil), owners: owners})
+		}
+	}
+	return rules, errors, scanner.Err()
+}
+
+// stripComment removes the comment of a line, from the first "#" that is not escaped
+func stripComment(line string) string {
+	for i := 0; i < len(line); i++ {
+		if line[i] == '\\' {
+			i++
+		} else if line[i] == '#' {
+			return line[:i]
+		}
+	}
+	return line
+}
+
+// Assignee returns the first owner who is a person rather than a team or a group, as a Jira
+// user: the name without "@", or the email address. It is empty when every owner is a team.
+func Assignee(owners []string) string {
+	for _, owner := range owners {
+		switch {
+		case !strings.HasPrefix(owner, "@"):
+			return owner
+		case strings.HasPrefix(owner, "@@") || strings.Contains(owner, "/"):
+			continue
+		default:
+			return strings.TrimPrefix(owner, "@")
+		}
+	}
+	return ""
+}
@@ -0,0 +1,288 @@
+package compose
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/s3pweb/gitArchiveS3Report/utils/languages"
+	"github.com/s3pweb/gitArchiveS3Report/utils/structs"
+	"gopkg.in/yaml.v3"
+)
+
+var (
+	// composeFileRegex matches docker-compose.yml, docker-compose.prod.yaml, ...
+	composeFileRegex = regexp.MustCompile(`(?i)^docker-compose.*\.ya?ml$`)
+
+	// routerRuleRegex matches Traefik v2 router rules and Traefik v1 frontend rules
+	routerRuleRegex = regexp.MustCompile(`^traefik\.(http\.routers\.[^.]+\.rule|frontend\.rule)$`)
+
+	// hostMatcherRegex matches the Host(...) and HostSNI(...) matchers of a Traefik v2 rule
+	hostMatcherRegex = regexp.MustCompile(`Host(?:SNI)?\(([^)]*)\)`)
+)
+
+// composeFile is the subset of the docker-compose format read by the report
+type composeFile struct {
+	Services map[string]composeService `yaml:"services"`
+}
+
+// composeService is the subset of a docker-compose service read by the report.
+// Ports, networks and labels accept both the list and the map syntaxes.
+type composeService struct {
+	Image    string        `yaml:"image"`
+	Ports    []interface{} `yaml:"ports"`
+	Networks interface{}   `yaml:"networks"`
+	Labels   interface{}   `yaml:"labels"`
+}
+
+// FindFiles returns the docker-compose files of a repository, relative to its root
+func FindFiles(repoPath string) ([]string, error) {
+	var files []string
+
+	err := filepath.WalkDir(repoPath, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if path != repoPath && languages.IsVendoredDir(d.Name()) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if composeFileRegex.MatchString(d.Name()) {
+			relPath, err := filepath.Rel(repoPath, path)
+			if err != nil {
+				return err
+			}
+			files = append(files, filepath.ToSlash(relPath))
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Strings(files)
+	return files, nil
+}
+
+// Analyze parses every docker-compose file of a repository and returns their services.
+// Files that cannot be parsed are reported in the returned error, the others are still analyzed.
+func Analyze(repoPath string) ([]string, []structs.ServiceInfo, error) {
+	files, err := FindFiles(repoPath)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var services []structs.ServiceInfo
+	var parseErrors []error
+	for _, file := range files {
+		fileServices, err := ParseFile(filepath.Join(repoPath, file), file)
+		if err != nil {
+			parseErrors = append(parseErrors, err)
+			continue
+		}
+		services = append(services, fileServices...)
+	}
+
+	return files, services, errors.Join(parseErrors...)
+}
+
+// ParseFile parses a docker-compose file and returns its services sorted by name.
+// The name is the path recorded in the File field of each service.
+func ParseFile(path, name string) ([]structs.ServiceInfo, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var file composeFile
+	if err := yaml.Unmarshal(content, &file); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", name, err)
+	}
+
+	var services []structs.ServiceInfo
+	for serviceName, service := range file.Services {
+		image, tag := SplitImage(service.Image)
+		rules := routerRules(service.Labels)
+
+		services = append(services, structs.ServiceInfo{
+			File:     name,
+			Name:     serviceName,
+			Image:    image,
+			Tag:      tag,
+			Ports:    parsePorts(service.Ports),
+			Networks: parseNetworks(service.Networks),
+			Rules:    rules,
+			Hosts:    hostsFromRules(rules),
+		})
+	}
+
+	sort.Slice(services, func(i, j int) bool {
+		return services[i].Name < services[j].Name
+	})
+	return services, nil
+}
+
+// SplitImage splits an image reference into its name and tag.
+// The tag is empty when the reference has neither a tag nor a digest.
+func SplitImage(reference string) (string, string) {
+	reference = strings.TrimSpace(reference)
+	if at := strings.Index(reference, "@"); at != -1 {
+		return reference[:at], reference[at+1:]
+	}
+
+	// A colon before the last slash belongs to the registry host, not to the tag
+	lastSlash := strings.LastIndex(reference, "/")
+	if colon := strings.LastIndex(reference, ":"); colon > lastSlash {
+		return reference[:colon], reference[colon+1:]
+	}
+	return reference, ""
+}
+
+// parsePorts reads the short ("127.0.0.1:8080:80/udp") and long port syntaxes
+func parsePorts(ports []interface{}) []structs.PortMapping {
+	var mappings []structs.PortMapping
+
+	for _, port := range ports {
+		switch value := port.(type) {
+		case map[string]interface{}:
+			mappings = append(mappings, structs.PortMapping{
+				HostIP:    toString(value["host_ip"]),
+				Published: toString(value["published"]),
+				Target:    toString(value["target"]),
+				Protocol:  toString(value["protocol"]),
+			})
+		default:
+			mappings = append(mappings, parseShortPort(toString(value)))
+		}
+	}
+
+	return mappings
+}
+
+// parseShortPort parses the [HOST_IP:][HOST_PORT:]CONTAINER_PORT[/PROTOCOL] syntax
+func parseShortPort(port string) structs.PortMapping {
+	var mapping structs.PortMapping
+
+	if slash := strings.LastIndex(port, "/"); slash != -1 {
+		mapping.Protocol = port[slash+1:]
+		port = port[:slash]
+	}
+
+	// IPv6 host addresses are written between brackets
+	if strings.HasPrefix(port, "[") {
+		if end := strings.Index(port, "]:"); end != -1 {
+			mapping.HostIP = port[1:end]
+			port = port[end+2:]
+		}
+	}
+
+	parts := strings.Split(port, ":")
+	switch len(parts) {
+	case 1:
+		mapping.Target = parts[0]
+	case 2:
+		mapping.Published, mapping.Target = parts[0], parts[1]
+	default:
+		mapping.HostIP = strings.Join(parts[:len(parts)-2], ":")
+		mapping.Published, mapping.Target = parts[len(parts)-2], parts[len(parts)-1]
+	}
+
+	return mapping
+}
+
+// parseNetworks reads the list and map syntaxes of the networks of a service
+func parseNetworks(networks interface{}) []string {
+	var names []string
+
+	switch value := networks.(type) {
+	case []interface{}:
+		for _, network := range value {
+			names = append(names, toString(network))
+		}
+	case map[string]interface{}:
+		for network := range value {
+			names = append(names, network)
+		}
+	}
+
+	sort.Strings(names)
+	return names
+}
+
+// routerRules returns the Traefik router rules declared in the labels of a service
+func routerRules(labels interface{}) []string {
+	var rules []string
+
+	for key, value := range parseLabels(labels) {
+		if routerRuleRegex.MatchString(key) {
+			rules = append(rules, value)
+		}
+	}
+
+	sort.Strings(rules)
+	return rules
+}
+
+// parseLabels reads the list ("key=value") and map syntaxes of the labels of a service
+func parseLabels(labels interface{}) map[string]string {
+	result := make(map[string]string)
+
+	switch value := labels.(type) {
+	case []interface{}:
+		for _, label := range value {
+			parts := strings.SplitN(toString(label), "=", 2)
+			if len(parts) == 2 {
+				result[strings.TrimSpace(parts[0])] = strings.TrimSpace(parts[1])
+			}
+		}
+	case map[string]interface{}:
+		for key, label := range value {
+			result[key] = toString(label)
+		}
+	}
+
+	return result
+}
+
+// hostsFromRules extracts the hostnames matched by Traefik v1 and v2 rules
+func hostsFromRules(rules []string) []string {
+	seen := make(map[string]bool)
+	var hosts []string
+
+	addHosts := func(list string) {
+		for _, host := range strings.Split(list, ",") {
+			host = strings.Trim(strings.TrimSpace(host), "`\"'")
+			if host != "" && !seen[host] {
+				seen[host] = true
+				hosts = append(hosts, host)
+			}
+		}
+	}
+
+	for _, rule := range rules {
+		// Traefik v1: "Host:a.example.com,b.example.com"
+		if strings.HasPrefix(rule, "Host:") {
+			addHosts(strings.TrimPrefix(rule, "Host:"))
+			continue
+		}
+		for _, match := range hostMatcherRegex.FindAllStringSubmatch(rule, -1) {
+			addHosts(match[1])
+		}
+	}
+
+	return hosts
+}
+
+func toString(value interface{}) string {
+	if value == nil {
+		return ""
+	}
+	return strings.TrimSpace(fmt.Sprintf("%v", value))
+}
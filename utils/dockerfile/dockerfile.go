@@ -0,0 +1,228 @@
+package dockerfile
+
+import (
+	"bufio"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/s3pweb/gitArchiveS3Report/utils/compose"
+	"github.com/s3pweb/gitArchiveS3Report/utils/languages"
+	"github.com/s3pweb/gitArchiveS3Report/utils/structs"
+)
+
+// dockerfileRegex matches Dockerfile, Dockerfile.prod, api.dockerfile, ...
+var dockerfileRegex = regexp.MustCompile(`(?i)^(dockerfile(\..+)?|.+\.dockerfile)$`)
+
+// notDockerfileRegex matches the documentation, backups and templates named after a Dockerfile,
+// such as Dockerfile.md, Dockerfile.bak or dockerfile.txt
+var notDockerfileRegex = regexp.MustCompile(`(?i)(\.(md|markdown|txt|rst|adoc|html|bak|old|orig|swp|tmp|backup)|~)$`)
+
+// Instruction is one logical instruction of a Dockerfile, with its continuation lines joined
+type Instruction struct {
+	Line      int
+	Command   string
+	Arguments string
+}
+
+// Stage is a build stage of a Dockerfile, starting at a FROM instruction
+type Stage struct {
+	Name         string
+	Image        string
+	Tag          string
+	FromStage    bool
+	Instructions []Instruction
+}
+
+// Dockerfile is a parsed Dockerfile
+type Dockerfile struct {
+	Path   string
+	Stages []Stage
+}
+
+// FindFiles returns the Dockerfiles of a repository, relative to its root
+func FindFiles(repoPath string) ([]string, error) {
+	var files []string
+
+	err := filepath.WalkDir(repoPath, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if path != repoPath && languages.IsVendoredDir(d.Name()) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if dockerfileRegex.MatchString(d.Name()) && !notDockerfileRegex.MatchString(d.Name()) {
+			relPath, err := filepath.Rel(repoPath, path)
+			if err != nil {
+				return err
+			}
+			files = append(files, filepath.ToSlash(relPath))
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Strings(files)
+	return files, nil
+}
+
+// Parse reads a Dockerfile and splits it into stages. The name is recorded as its path.
+func Parse(path, name string) (*Dockerfile, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	dockerfile := &Dockerfile{Path: name}
+	stageNames := make(map[string]bool)
+
+	var current strings.Builder
+	startLine := 0
+	lineNumber := 0
+
+	flush := func() {
+		text := strings.TrimSpace(current.String())
+		current.Reset()
+		if text == "" {
+			return
+		}
+
+		instruction := Instruction{Line: startLine, Command: strings.ToUpper(text)}
+		if separator := strings.IndexAny(text, " \t"); separator != -1 {
+			instruction.Command = strings.ToUpper(text[:separator])
+			instruction.Arguments = strings.TrimSpace(text[separator+1:])
+		}
+
+		if instruction.Command == "FROM" {
+			dockerfile.Stages = append(dockerfile.Stages, newStage(instruction.Arguments, stageNames))
+		}
+		if len(dockerfile.Stages) > 0 {
+			stage := &dockerfile.Stages[len(dockerfile.Stages)-1]
+			stage.Instructions = append(stage.Instructions, instruction)
+		}
+	}
+
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		lineNumber++
+		line := strings.TrimSpace(scanner.Text())
+		if strings.HasPrefix(line, "#") {
+			continue
+		}
+		if current.Len() == 0 {
+			if line == "" {
+				continue
+			}
+			startLine = lineNumber
+		}
+
+		if strings.HasSuffix(line, "\\") {
+			current.WriteString(strings.TrimSuffix(line, "\\") + " ")
+			continue
+		}
+		current.WriteString(line)
+		flush()
+	}
+	flush()
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return dockerfile, nil
+}
+
+// newStage creates a stage from the arguments of a FROM instruction:
+// [--platform=<platform>] <image>[:<tag>|@<digest>] [AS <name>]
+func newStage(arguments string, stageNames map[string]bool) Stage {
+	var fields []string
+	for _, field := range strings.Fields(arguments) {
+		if !strings.HasPrefix(field, "--") {
+			fields = append(fields, field)
+		}
+	}
+
+	var stage Stage
+	if len(fields) == 0 {
+		return stage
+	}
+	if len(fields) >= 3 && strings.EqualFold(fields[1], "AS") {
+		stage.Name = strings.ToLower(fields[2])
+	}
+
+	// A FROM referencing a previous stage does not pull a base image
+	if stageNames[strings.ToLower(fields[0])] {
+		stage.Image = strings.ToLower(fields[0])
+		stage.FromStage = true
+	} else {
+		stage.Image, stage.Tag = compose.SplitImage(fields[0])
+	}
+	if stage.Name != "" {
+		stageNames[stage.Name] = true
+	}
+	return stage
+}
+
+// BaseImages returns the images pulled by the Dockerfile, ignoring references to previous stages
+func (d *Dockerfile) BaseImages() []string {
+	var images []string
+	for _, stage := range d.Stages {
+		if !stage.FromStage && stage.Image != "" && stage.Image != "scratch" {
+			image := stage.Image
+			if strings.Contains(stage.Tag, ":") {
+				image += "@" + stage.Tag
+			} else if stage.Tag != "" {
+				image += ":" + stage.Tag
+			}
+			images = append(images, image)
+		}
+	}
+	return images
+}
+
+// Analyze parses and lints every Dockerfile of a repository. Files without a FROM instruction are
+// not Dockerfiles and are left out. Files that cannot be read are reported in the returned error,
+// the others are still analyzed.
+func Analyze(repoPath string) ([]string, []string, []structs.DockerFinding, error) {
+	files, err := FindFiles(repoPath)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	seen := make(map[string]bool)
+	var dockerfiles, baseImages []string
+	var findings []structs.DockerFinding
+	var parseErrors []error
+
+	for _, file := range files {
+		dockerfile, err := Parse(filepath.Join(repoPath, file), file)
+		if err != nil {
+			parseErrors = append(parseErrors, err)
+			dockerfiles = append(dockerfiles, file)
+			continue
+		}
+		if len(dockerfile.Stages) == 0 {
+			continue
+		}
+		dockerfiles = append(dockerfiles, file)
+		for _, image := range dockerfile.BaseImages() {
+			if !seen[image] {
+				seen[image] = true
+				baseImages = append(baseImages, image)
+			}
+		}
+		findings = append(findings, Lint(dockerfile)...)
+	}
+
+	return dockerfiles, baseImages, findings, errors.Join(parseErrors...)
+}
@@ -0,0 +1,184 @@
+package dockerfile
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/s3pweb/gitArchiveS3Report/utils/structs"
+)
+
+// Lint rule identifiers
+const (
+	RuleUntaggedBaseImage  = "untagged-base-image"
+	RuleLatestBaseImage    = "latest-base-image"
+	RuleRootUser           = "root-user"
+	RuleMissingHealthcheck = "missing-healthcheck"
+	RuleRemoteAdd          = "remote-add"
+	RuleSecretInBuildArg   = "secret-in-build-arg"
+)
+
+var (
+	// secretNameRegex matches variable names that usually hold credentials
+	secretNameRegex = regexp.MustCompile(`(?i)(password|passwd|secret|token|api_?key|private_?key|access_?key|credentials)`)
+
+	// remoteSourceRegex matches ADD sources downloaded from the network
+	remoteSourceRegex = regexp.MustCompile(`(?i)^https?://`)
+)
+
+// Lint checks a Dockerfile against the lint rules and returns its findings
+func Lint(d *Dockerfile) []structs.DockerFinding {
+	var findings []structs.DockerFinding
+	add := func(line int, rule, severity, format string, args ...interface{}) {
+		findings = append(findings, structs.DockerFinding{
+			File:     d.Path,
+			Line:     line,
+			Rule:     rule,
+			Severity: severity,
+			Message:  fmt.Sprintf(format, args...),
+		})
+	}
+
+	for _, stage := range d.Stages {
+		for _, instruction := range stage.Instructions {
+			switch instruction.Command {
+			case "FROM":
+				if stage.FromStage || stage.Image == "scratch" || strings.Contains(stage.Image, "$") {
+					continue
+				}
+				if stage.Tag == "" {
+					add(instruction.Line, RuleUntaggedBaseImage, structs.SeverityMedium,
+						"base image %s has no tag and resolves to latest", stage.Image)
+				} else if stage.Tag == "latest" {
+					add(instruction.Line, RuleLatestBaseImage, structs.SeverityMedium,
+						"base image %s uses the latest tag", stage.Image)
+				}
+			case "ADD":
+				for _, source := range addSources(instruction.Arguments) {
+					if remoteSourceRegex.MatchString(source) {
+						add(instruction.Line, RuleRemoteAdd, structs.SeverityMedium,
+							"ADD downloads %s, use curl or wget in a RUN with a checksum instead", source)
+					}
+				}
+			case "ARG", "ENV":
+				for _, name := range variableNames(instruction.Command, instruction.Arguments) {
+					if secretNameRegex.MatchString(name) {
+						add(instruction.Line, RuleSecretInBuildArg, structs.SeverityHigh,
+							"%s %s passes a secret that is stored in the image history, use a build secret instead", instruction.Command, name)
+					}
+				}
+			}
+		}
+	}
+
+	if len(d.Stages) == 0 {
+		return findings
+	}
+
+	// USER and HEALTHCHECK only matter in the final stage, which is the image that runs. A final
+	// stage built FROM an earlier stage inherits them, so the closest stage that sets them counts.
+	final := d.Stages[len(d.Stages)-1]
+	fromLine := 0
+	if len(final.Instructions) > 0 {
+		fromLine = final.Instructions[0].Line
+	}
+
+	user := ""
+	userLine := fromLine
+	userSet, healthcheckSet := false, false
+	hasHealthcheck := false
+	for _, stage := range d.stageChain(len(d.Stages) - 1) {
+		stageUser, stageUserLine, stageHealthcheck := "", 0, ""
+		for _, instruction := range stage.Instructions {
+			switch instruction.Command {
+			case "USER":
+				stageUser = strings.SplitN(instruction.Arguments, ":", 2)[0]
+				stageUserLine = instruction.Line
+			case "HEALTHCHECK":
+				stageHealthcheck = instruction.Arguments
+			}
+		}
+		if !userSet && stageUser != "" {
+			user, userLine, userSet = stageUser, stageUserLine, true
+		}
+		if !healthcheckSet && stageHealthcheck != "" {
+			hasHealthcheck = !strings.EqualFold(strings.TrimSpace(stageHealthcheck), "NONE")
+			healthcheckSet = true
+		}
+	}
+
+	if user == "" {
+		add(fromLine, RuleRootUser, structs.SeverityHigh, "no USER instruction, the container runs as root")
+	} else if user == "root" || user == "0" {
+		add(userLine, RuleRootUser, structs.SeverityHigh, "USER %s makes the container run as root", user)
+	}
+	if !hasHealthcheck {
+		add(fromLine, RuleMissingHealthcheck, structs.SeverityLow, "no HEALTHCHECK instruction")
+	}
+
+	return findings
+}
+
+// stageChain returns a stage followed by the earlier stages it is built FROM, the closest first
+func (d *Dockerfile) stageChain(index int) []Stage {
+	var chain []Stage
+	for index >= 0 {
+		stage := d.Stages[index]
+		chain = append(chain, stage)
+		if !stage.FromStage {
+			break
+		}
+		parent := -1
+		for i := index - 1; i >= 0; i-- {
+			if d.Stages[i].Name == stage.Image {
+				parent = i
+				break
+			}
+		}
+		index = parent
+	}
+	return chain
+}
+
+// addSources returns the sources of an ADD instruction, in shell or JSON form
+func addSources(arguments string) []string {
+	var fields []string
+	if strings.HasPrefix(arguments, "[") {
+		for _, field := range strings.Split(strings.Trim(arguments, "[]"), ",") {
+			fields = append(fields, strings.Trim(strings.TrimSpace(field), `"`))
+		}
+	} else {
+		for _, field := range strings.Fields(arguments) {
+			if !strings.HasPrefix(field, "--") {
+				fields = append(fields, field)
+			}
+		}
+	}
+
+	// The last field is the destination
+	if len(fields) < 2 {
+		return nil
+	}
+	return fields[:len(fields)-1]
+}
+
+// variableNames returns the variables declared by an ARG or ENV instruction
+func variableNames(command, arguments string) []string {
+	fields := strings.Fields(arguments)
+	if len(fields) == 0 {
+		return nil
+	}
+
+	// Legacy form: ENV KEY value with spaces
+	if command == "ENV" && !strings.Contains(fields[0], "=") {
+		return []string{fields[0]}
+	}
+
+	var names []string
+	for _, field := range fields {
+		if name := strings.SplitN(field, "=", 2)[0]; name != "" && (command == "ARG" || strings.Contains(field, "=")) {
+			names = append(names, name)
+		}
+	}
+	return names
+}
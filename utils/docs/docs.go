@@ -0,0 +1,167 @@
+package docs
+
+import (
+	"bufio"
+	"math"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/s3pweb/gitArchiveS3Report/utils/structs"
+)
+
+// Weights of the checks in the documentation score, out of 100
+const (
+	readmeWeight    = 40
+	wordsWeight     = 20
+	sectionsWeight  = 20
+	docsWeight      = 10
+	changelogWeight = 10
+)
+
+var (
+	// readmeRegex matches README, README.md, readme.rst, ...
+	readmeRegex = regexp.MustCompile(`(?i)^readme(\.(md|markdown|rst|txt|adoc))?$`)
+
+	// changelogRegex matches CHANGELOG, CHANGELOG.md, CHANGES.txt, HISTORY.md, ...
+	changelogRegex = regexp.MustCompile(`(?i)^(changelog|changes|history)(\.(md|markdown|rst|txt|adoc))?$`)
+
+	// headingRegex matches the ATX headings of Markdown ("## Install") and the titles of AsciiDoc ("== Install")
+	headingRegex = regexp.MustCompile(`^\s{0,3}(#{1,6}|={1,6})\s+(.+?)\s*[#=]*\s*$`)
+
+	// underlineRegex matches the underlines of the setext headings of Markdown and of the titles of reStructuredText
+	underlineRegex = regexp.MustCompile(`^\s*(=+|-+|~+|\^+)\s*$`)
+
+	// wordRegex matches the words of a text, ignoring the Markdown markup
+	wordRegex = regexp.MustCompile(`[\p{L}\p{N}][\p{L}\p{N}'’._-]*`)
+)
+
+// docsFolders are the names of the documentation folders at the root of a repository
+var docsFolders = map[string]bool{"docs": true, "doc": true, "documentation": true}
+
+// Analyze checks the documentation at the root of a repository: the README, its word count and
+// the required sections among its headings, the documentation folder and the changelog.
+// A required section is found when a heading contains it, ignoring the case, so that
+// "Install" is found in "Installation guide".
+func Analyze(repoPath string, requiredSections []string, minWords int) (structs.DocsInfo, error) {
+	var info structs.DocsInfo
+
+	entries, err := os.ReadDir(repoPath)
+	if err != nil {
+		return info, err
+	}
+	for _, entry := range entries {
+		name := entry.Name()
+		switch {
+		case entry.IsDir() && docsFolders[strings.ToLower(name)]:
+			info.HasDocsFolder = true
+		case entry.IsDir():
+		case readmeRegex.MatchString(name) && (info.ReadmeFile == "" || isMarkdown(name)):
+			info.ReadmeFile = name
+		case changelogRegex.MatchString(name):
+			info.HasChangelog = true
+		}
+	}
+
+	var headings []string
+	if info.ReadmeFile != "" {
+		info.HasReadme = true
+		info.Words, headings, err = readReadme(filepath.Join(repoPath, info.ReadmeFile))
+		if err != nil {
+			return info, err
+		}
+	}
+
+	for _, section := range requiredSections {
+		if hasHeading(headings, section) {
+			info.Sections = append(info.Sections, section)
+		} else {
+			info.MissingSections = append(info.MissingSections, section)
+		}
+	}
+
+	info.Score = math.Round(score(info, len(requiredSections), minWords))
+	return info, nil
+}
+
+// isMarkdown reports whether a file is a Markdown file, preferred when a repository has several READMEs
+func isMarkdown(name string) bool {
+	ext := strings.ToLower(filepath.Ext(name))
+	return ext == ".md" || ext == ".markdown"
+}
+
+// readReadme counts the words of a README, outside of its code blocks, and returns its headings
+func readReadme(path string) (int, []string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return 0, nil, err
+	}
+	defer file.Close()
+
+	words := 0
+	var headings []string
+	inCode := false
+	previous := ""
+
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := scanner.Text()
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~") {
+			inCode = !inCode
+			previous = ""
+			continue
+		}
+		if inCode {
+			continue
+		}
+
+		if match := headingRegex.FindStringSubmatch(line); match != nil {
+			headings = append(headings, match[2])
+		} else if underlineRegex.MatchString(line) && previous != "" {
+			headings = append(headings, previous)
+		}
+		words += len(wordRegex.FindAllString(line, -1))
+		previous = trimmed
+	}
+	return words, headings, scanner.Err()
+}
+
+// hasHeading reports whether one of the headings contains a section name, ignoring the case
+func hasHeading(headings []string, section string) bool {
+	section = strings.ToLower(strings.TrimSpace(section))
+	for _, heading := range headings {
+		if strings.Contains(strings.ToLower(heading), section) {
+			return true
+		}
+	}
+	return false
+}
+
+// score weighs the checks of the documentation: the README, its length up to the minimum word
+// count, the share of the required sections it has, the documentation folder and the changelog
+func score(info structs.DocsInfo, required, minWords int) float64 {
+	total := 0.0
+	if info.HasReadme {
+		total += readmeWeight
+		if minWords <= 0 || info.Words >= minWords {
+			total += wordsWeight
+		} else {
+			total += wordsWeight * float64(info.Words) / float64(minWords)
+		}
+		if required == 0 {
+			total += sectionsWeight
+		} else {
+			total += sectionsWeight * float64(len(info.Sections)) / float64(required)
+		}
+	}
+	if info.HasDocsFolder {
+		total += docsWeight
+	}
+	if info.HasChangelog {
+		total += changelogWeight
+	}
+	return total
+}
@@ -1,8 +1,6 @@
 package excel
 
 import (
-	"fmt"
-
 	"github.com/xuri/excelize/v2"
 )
 
@@ -315,9 +313,9 @@ func HighCountStyle(f *excelize.File) (int, error) {
 	return highCountStyle, err
 }
 
-func SetOneHeader(f *excelize.File, sheet string, header string, column rune) {
+func SetOneHeader(f *excelize.File, sheet string, header string, column int) {
 	headerStyle, _ := CreateHeaderStyle(f)
-	cell := fmt.Sprintf("%c1", column)
+	cell, _ := excelize.CoordinatesToCellName(column, 1)
 	f.SetCellValue(sheet, cell, header)
 	f.SetCellStyle(sheet, cell, cell, headerStyle)
 }
@@ -10,6 +10,8 @@ import (
 	"text/template"
 
 	"github.com/s3pweb/gitArchiveS3Report/config"
+	"github.com/s3pweb/gitArchiveS3Report/utils/codeowners"
+	"github.com/s3pweb/gitArchiveS3Report/utils/rules"
 	"github.com/s3pweb/gitArchiveS3Report/utils/structs"
 	"github.com/xuri/excelize/v2"
 )
@@ -20,6 +22,7 @@ type JiraTaskData struct {
 	BranchName      string
 	TopDeveloper    string
 	LastDeveloper   string
+	Assignee        string
 	MissingElements string
 	ParentTask      string
 }
@@ -80,13 +83,18 @@ func AddJiraButtons(f *excelize.File, sheet string, branchesInfo []structs.Branc
 	columns = append(columns, cfg.App.FilesToSearch...)
 	columns = append(columns, cfg.App.TermsToSearch...)
 	columns = append(columns, cfg.App.ForbiddenFiles...)
-	lastColumn := rune('A' + len(columns))
+	columns = append(columns, rules.CustomIDs(cfg.App.Rules)...)
+	lastColumn := len(columns) + 1
+	lastColumnName, err := excelize.ColumnNumberToName(lastColumn)
+	if err != nil {
+		return err
+	}
 
 	// Add JIRA column header
-	jiraCell := fmt.Sprintf("%c1", lastColumn)
+	jiraCell := lastColumnName + "1"
 	f.SetCellValue(sheet, jiraCell, "CREATE JIRA TASK")
 	f.SetCellStyle(sheet, jiraCell, jiraCell, headerStyle)
-	f.SetColWidth(sheet, string(lastColumn), string(lastColumn), 25)
+	f.SetColWidth(sheet, lastColumnName, lastColumnName, 25)
 
 	// Pre-compile templates
 	titleTmpl, err := template.New("title").Parse(cfg.App.JiraTitleTemplate)
@@ -132,13 +140,39 @@ func AddJiraButtons(f *excelize.File, sheet string, branchesInfo []structs.Branc
 		// Check forbidden files that exist
 		for file, exists := range branchInfo.ForbiddenFiles {
 			if exists {
-				cleanFile := cleanRegexPattern(file)
-				filesToRemove = append(filesToRemove, cleanFile)
+				// List the files actually found, or the pattern when they are unknown
+				if paths := branchInfo.RulePaths[file]; len(paths) > 0 {
+					filesToRemove = append(filesToRemove, paths...)
+				} else {
+					filesToRemove = append(filesToRemove, cleanRegexPattern(file))
+				}
+			}
+		}
+
+		// Check failed compliance rules, in the order of the rules file
+		var failedRules []string
+		for _, id := range rules.CustomIDs(cfg.App.Rules) {
+			if result, exists := branchInfo.RuleResults[id]; exists && !result.Passed {
+				failedRule := fmt.Sprintf("[%s] %s", result.Severity, result.Title)
+				if len(result.Paths) > 0 {
+					failedRule += " (" + strings.Join(result.Paths, ", ") + ")"
+				}
+				failedRules = append(failedRules, failedRule)
+			}
+		}
+
+		// Check the documentation: a missing README, or the README sections that are missing
+		var docsToComplete []string
+		if !branchInfo.Docs.HasReadme {
+			docsToComplete = append(docsToComplete, "README")
+		} else {
+			for _, section := range branchInfo.Docs.MissingSections {
+				docsToComplete = append(docsToComplete, "Section README : "+section)
 			}
 		}
 
-		// If there are missing elements or forbidden files, create a JIRA task link
-		if len(elementsToAdd) > 0 || len(filesToRemove) > 0 {
+		// If there are missing elements, forbidden files, failed rules, Docker findings or documentation to complete, create a JIRA task link
+		if len(elementsToAdd) > 0 || len(filesToRemove) > 0 || len(failedRules) > 0 || len(branchInfo.DockerFindings) > 0 || len(branchInfo.CI.Gaps) > 0 || len(docsToComplete) > 0 {
 			// Build a well-formatted description with clear sections
 			var descriptionBuilder strings.Builder
 
@@ -160,6 +194,42 @@ func AddJiraButtons(f *excelize.File, sheet string, branchesInfo []structs.Branc
 				descriptionBuilder.WriteString("\n")
 			}
 
+			// Add section for failed compliance rules
+			if len(failedRules) > 0 {
+				descriptionBuilder.WriteString("Règles non respectées :\n")
+				for _, rule := range failedRules {
+					descriptionBuilder.WriteString("- " + rule + "\n")
+				}
+				descriptionBuilder.WriteString("\n")
+			}
+
+			// Add section for CI/CD gaps
+			if len(branchInfo.CI.Gaps) > 0 {
+				descriptionBuilder.WriteString("Manques CI/CD :\n")
+				for _, gap := range branchInfo.CI.Gaps {
+					descriptionBuilder.WriteString("- " + gap + "\n")
+				}
+				descriptionBuilder.WriteString("\n")
+			}
+
+			// Add section for missing documentation
+			if len(docsToComplete) > 0 {
+				descriptionBuilder.WriteString("Documentation manquante :\n")
+				for _, doc := range docsToComplete {
+					descriptionBuilder.WriteString("- " + doc + "\n")
+				}
+				descriptionBuilder.WriteString("\n")
+			}
+
+			// Add section for Dockerfile findings
+			if len(branchInfo.DockerFindings) > 0 {
+				descriptionBuilder.WriteString("Problèmes Docker :\n")
+				for _, finding := range branchInfo.DockerFindings {
+					descriptionBuilder.WriteString("- " + FormatDockerFinding(finding) + "\n")
+				}
+				descriptionBuilder.WriteString("\n")
+			}
+
 			// Add additional documentation links
 			if len(cfg.App.JiraDocLinks) > 0 {
 				descriptionBuilder.WriteString("\n\nDocumentation : \n")
@@ -179,12 +249,19 @@ func AddJiraButtons(f *excelize.File, sheet string, branchesInfo []structs.Branc
 			// Add parent JIRA reference
 			descriptionBuilder.WriteString("JIRA parente: " + cfg.App.JiraParentTask)
 
+			// The task is assigned to the first person named in CODEOWNERS, or else to the top developer
+			assignee := codeowners.Assignee(branchInfo.Ownership.Owners)
+			if assignee == "" {
+				assignee = branchInfo.TopDeveloper
+			}
+
 			// Use this formatted description in the template data
 			data := JiraTaskData{
 				RepoName:        branchInfo.RepoName,
 				BranchName:      branchInfo.BranchName,
 				TopDeveloper:    branchInfo.TopDeveloper,
 				LastDeveloper:   branchInfo.LastDeveloper,
+				Assignee:        assignee,
 				MissingElements: descriptionBuilder.String(),
 				ParentTask:      cfg.App.JiraParentTask,
 			}
@@ -204,7 +281,7 @@ func AddJiraButtons(f *excelize.File, sheet string, branchesInfo []structs.Branc
 			description := descBuf.String()
 
 			// Add button to the cell
-			cell := fmt.Sprintf("%c%d", lastColumn, row)
+			cell, _ := excelize.CoordinatesToCellName(lastColumn, row)
 
 			// If the JIRA API token and username are set, create a link to our local server
 			if cfg.App.JiraAPIToken != "" && cfg.App.JiraUsername != "" {
@@ -212,7 +289,7 @@ func AddJiraButtons(f *excelize.File, sheet string, branchesInfo []structs.Branc
 				jiraLink := fmt.Sprintf("http://localhost:8081/create-jira-ticket?title=%s&description=%s&assignee=%s&parent=%s",
 					url.QueryEscape(title),
 					url.QueryEscape(description),
-					url.QueryEscape(branchInfo.TopDeveloper),
+					url.QueryEscape(assignee),
 					url.QueryEscape(cfg.App.JiraParentTask))
 
 				// Create a hyperlink in the cell
@@ -271,6 +348,11 @@ func AddJiraButtons(f *excelize.File, sheet string, branchesInfo []structs.Branc
 	return nil
 }
 
+// FormatDockerFinding formats a Dockerfile finding as "file:line [severity] message"
+func FormatDockerFinding(finding structs.DockerFinding) string {
+	return fmt.Sprintf("%s:%d [%s] %s", finding.File, finding.Line, finding.Severity, finding.Message)
+}
+
 // cleanRegexPattern cleans up the regex pattern for better readability
 func cleanRegexPattern(pattern string) string {
 	pattern = strings.Replace(pattern, "(?i)", "", -1)
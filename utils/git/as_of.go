@@ -0,0 +1,91 @@
+package gitUtils
+
+import (
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/filemode"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/plumbing/storer"
+)
+
+// ErrNoCommitBefore is returned when a branch has no commit before the requested date
+var ErrNoCommitBefore = errors.New("no commit before the date")
+
+// BranchHashBefore resolves a branch to its last commit made before a date, by committer date.
+// A zero date resolves the branch to its current last commit.
+func BranchHashBefore(repo *git.Repository, branch string, before time.Time) (plumbing.Hash, error) {
+	hash, err := BranchHash(repo, branch)
+	if err != nil || before.IsZero() {
+		return hash, err
+	}
+	return CommitBefore(repo, hash, before)
+}
+
+// CommitBefore returns the most recent commit reachable from a commit and made before a date
+func CommitBefore(repo *git.Repository, from plumbing.Hash, before time.Time) (plumbing.Hash, error) {
+	commitIter, err := repo.Log(&git.LogOptions{From: from, Order: git.LogOrderCommitterTime})
+	if err != nil {
+		return plumbing.ZeroHash, err
+	}
+	defer commitIter.Close()
+
+	found := plumbing.ZeroHash
+	err = commitIter.ForEach(func(c *object.Commit) error {
+		if c.Committer.When.Before(before) {
+			found = c.Hash
+			return storer.ErrStop
+		}
+		return nil
+	})
+	if err != nil {
+		return plumbing.ZeroHash, err
+	}
+	if found.IsZero() {
+		return plumbing.ZeroHash, ErrNoCommitBefore
+	}
+	return found, nil
+}
+
+// WriteTree writes the files of a commit into a directory, leaving the working copy of the
+// repository untouched. Symbolic links and submodules are not written.
+func WriteTree(repo *git.Repository, hash plumbing.Hash, dir string) error {
+	commit, err := repo.CommitObject(hash)
+	if err != nil {
+		return err
+	}
+	files, err := commit.Files()
+	if err != nil {
+		return err
+	}
+	return files.ForEach(func(file *object.File) error {
+		if file.Mode != filemode.Regular && file.Mode != filemode.Executable && file.Mode != filemode.Deprecated {
+			return nil
+		}
+		target := filepath.Join(dir, filepath.FromSlash(file.Name))
+		if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+			return err
+		}
+
+		reader, err := file.Reader()
+		if err != nil {
+			return err
+		}
+		defer reader.Close()
+
+		output, err := os.Create(target)
+		if err != nil {
+			return err
+		}
+		if _, err := io.Copy(output, reader); err != nil {
+			output.Close()
+			return err
+		}
+		return output.Close()
+	})
+}
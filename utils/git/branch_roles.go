@@ -0,0 +1,58 @@
+package gitUtils
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Roles of the branches of a repository. The main role is the default branch, and the roles of the
+// other branches come from BRANCH_ROLES.
+const (
+	RoleMain    = "main"
+	RoleDevelop = "develop"
+)
+
+// BranchRole is a role and the pattern of the names of the branches that have it
+type BranchRole struct {
+	Role    string
+	Pattern *regexp.Regexp
+}
+
+// ParseBranchRoles parses a ";"-separated list of role=pattern mappings, e.g.
+// "develop=develop|dev|staging;release=release/.*". A pattern must match the whole name of a
+// branch, without its "origin/" prefix. The first matching role wins.
+func ParseBranchRoles(value string) ([]BranchRole, error) {
+	var roles []BranchRole
+	for _, mapping := range strings.Split(value, ";") {
+		mapping = strings.TrimSpace(mapping)
+		if mapping == "" {
+			continue
+		}
+		parts := strings.SplitN(mapping, "=", 2)
+		if len(parts) != 2 || strings.TrimSpace(parts[0]) == "" || strings.TrimSpace(parts[1]) == "" {
+			return nil, fmt.Errorf("invalid mapping %q, expected role=pattern", mapping)
+		}
+		pattern, err := regexp.Compile("^(?:" + strings.TrimSpace(parts[1]) + ")$")
+		if err != nil {
+			return nil, fmt.Errorf("invalid pattern of role %s: %w", parts[0], err)
+		}
+		roles = append(roles, BranchRole{Role: strings.TrimSpace(parts[0]), Pattern: pattern})
+	}
+	return roles, nil
+}
+
+// RoleOf returns the role of a branch: main for the default branch, otherwise the first role whose
+// pattern matches its name, or "" when none does
+func RoleOf(branch, defaultBranch string, roles []BranchRole) string {
+	if branch == defaultBranch && defaultBranch != "" {
+		return RoleMain
+	}
+	name := strings.TrimPrefix(branch, "origin/")
+	for _, role := range roles {
+		if role.Pattern.MatchString(name) {
+			return role.Role
+		}
+	}
+	return ""
+}
@@ -0,0 +1,100 @@
+package gitUtils
+
+import (
+	"strings"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+)
+
+// defaultBranchNames are the usual names of the default branch, by priority
+var defaultBranchNames = []string{"main", "master"}
+
+// DefaultBranch returns the default branch of a repository among its branches: the branch origin/HEAD
+// points to, else the default branch of the remote when it is known (e.g. from the Bitbucket API), else
+// "main" or "master" when one exists, otherwise the branch checked out in the clone
+func DefaultBranch(repo *git.Repository, branches []string, remoteDefault string) string {
+	existing := make(map[string]bool)
+	for _, branch := range branches {
+		existing[branch] = true
+	}
+	candidates := append([]string{OriginHead(repo), remoteDefault}, defaultBranchNames...)
+	for _, name := range candidates {
+		if name == "" {
+			continue
+		}
+		if existing[name] {
+			return name
+		}
+		if existing["origin/"+name] {
+			return "origin/" + name
+		}
+	}
+
+	head, err := repo.Head()
+	if err == nil && head.Name().IsBranch() && existing[head.Name().Short()] {
+		return head.Name().Short()
+	}
+	return ""
+}
+
+// OriginHead returns the branch that origin/HEAD points to, which git clone sets to the default
+// branch of the remote, or "" when the clone has no origin/HEAD
+func OriginHead(repo *git.Repository) string {
+	ref, err := repo.Reference(plumbing.NewRemoteHEADReferenceName("origin"), false)
+	if err != nil || ref.Type() != plumbing.SymbolicReference {
+		return ""
+	}
+	return strings.TrimPrefix(ref.Target().String(), "refs/remotes/origin/")
+}
+
+// BranchHash resolves a branch name, local or "origin/"-prefixed, to the hash of its last commit
+func BranchHash(repo *git.Repository, branch string) (plumbing.Hash, error) {
+	name := plumbing.NewBranchReferenceName(branch)
+	if strings.HasPrefix(branch, "origin/") {
+		name = plumbing.NewRemoteReferenceName("origin", strings.TrimPrefix(branch, "origin/"))
+	}
+	ref, err := repo.Reference(name, true)
+	if err != nil {
+		return plumbing.ZeroHash, err
+	}
+	return ref.Hash(), nil
+}
+
+// Ancestors returns the hashes of the commits reachable from a commit, the commit included
+func Ancestors(repo *git.Repository, from plumbing.Hash) (map[plumbing.Hash]bool, error) {
+	commitIter, err := repo.Log(&git.LogOptions{From: from})
+	if err != nil {
+		return nil, err
+	}
+	defer commitIter.Close()
+
+	ancestors := make(map[plumbing.Hash]bool)
+	err = commitIter.ForEach(func(c *object.Commit) error {
+		ancestors[c.Hash] = true
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return ancestors, nil
+}
+
+// AheadBehind counts the commits of a branch that are not in the base branch (ahead)
+// and the commits of the base branch that are not in the branch (behind), from their ancestors
+func AheadBehind(branch, base map[plumbing.Hash]bool) (int, int) {
+	ahead := 0
+	for hash := range branch {
+		if !base[hash] {
+			ahead++
+		}
+	}
+	behind := 0
+	for hash := range base {
+		if !branch[hash] {
+			behind++
+		}
+	}
+	return ahead, behind
+}
@@ -0,0 +1,63 @@
+package gitUtils
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+)
+
+// semverRegex matches semantic versions, with an optional "v" prefix
+var semverRegex = regexp.MustCompile(`^v?(0|[1-9]\d*)\.(0|[1-9]\d*)\.(0|[1-9]\d*)(-[0-9A-Za-z.-]+)?(\+[0-9A-Za-z.-]+)?$`)
+
+// IsSemver reports whether a tag name is a semantic version such as v1.2.3 or 1.2.3-rc.1
+func IsSemver(name string) bool {
+	return semverRegex.MatchString(name)
+}
+
+// Tags returns the tags of a repository, the most recent first
+func Tags(repo *git.Repository) ([]Tag, error) {
+	tagRefs, err := repo.Tags()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tags: %w", err)
+	}
+
+	var tags []Tag
+	err = tagRefs.ForEach(func(ref *plumbing.Reference) error {
+		tag := Tag{Name: ref.Name().Short()}
+
+		// Annotated tags point to a tag object, lightweight tags directly to a commit
+		if tagObject, err := repo.TagObject(ref.Hash()); err == nil {
+			commit, err := tagObject.Commit()
+			if err != nil {
+				// Tags of trees or blobs are not releases
+				return nil
+			}
+			tag.Commit = commit.Hash
+			tag.Date = tagObject.Tagger.When
+		} else {
+			commit, err := repo.CommitObject(ref.Hash())
+			if err != nil {
+				return nil
+			}
+			tag.Commit = commit.Hash
+			tag.Date = commit.Committer.When
+		}
+
+		tags = append(tags, tag)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to iterate over tags: %w", err)
+	}
+
+	sort.Slice(tags, func(i, j int) bool {
+		if tags[i].Date.Equal(tags[j].Date) {
+			return tags[i].Name > tags[j].Name
+		}
+		return tags[i].Date.After(tags[j].Date)
+	})
+	return tags, nil
+}
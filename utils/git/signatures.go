@@ -0,0 +1,59 @@
+package gitUtils
+
+import (
+	"os"
+	"strings"
+
+	"github.com/ProtonMail/go-crypto/openpgp"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+)
+
+// Kinds of commit signatures
+const (
+	SignatureGPG  = "gpg"
+	SignatureSSH  = "ssh"
+	SignatureX509 = "x509"
+)
+
+// SignatureKind returns the kind of the signature of a commit, or "" when the commit is not signed
+func SignatureKind(c *object.Commit) string {
+	signature := strings.TrimSpace(c.PGPSignature)
+	switch {
+	case signature == "":
+		return ""
+	case strings.HasPrefix(signature, "-----BEGIN SSH SIGNATURE-----"):
+		return SignatureSSH
+	case strings.HasPrefix(signature, "-----BEGIN SIGNED MESSAGE-----"):
+		return SignatureX509
+	}
+	return SignatureGPG
+}
+
+// LoadKeyring reads an armored file of trusted public keys
+func LoadKeyring(path string) (openpgp.EntityList, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	return openpgp.ReadArmoredKeyRing(file)
+}
+
+// VerifySignature checks the GPG signature of a commit against a keyring. SSH and X.509
+// signatures cannot be checked with a keyring and are never verified.
+func VerifySignature(c *object.Commit, keyring openpgp.EntityList) bool {
+	if len(keyring) == 0 || SignatureKind(c) != SignatureGPG {
+		return false
+	}
+	encoded := &plumbing.MemoryObject{}
+	if err := c.EncodeWithoutSignature(encoded); err != nil {
+		return false
+	}
+	reader, err := encoded.Reader()
+	if err != nil {
+		return false
+	}
+	_, err = openpgp.CheckArmoredDetachedSignature(keyring, reader, strings.NewReader(c.PGPSignature), nil)
+	return err == nil
+}
@@ -0,0 +1,176 @@
+package gitUtils
+
+import (
+	"context"
+	"io/fs"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+)
+
+// DiskSize returns the size on disk of the objects of a repository, loose and packed,
+// and the size of its pack files alone
+func DiskSize(repoPath string) (int64, int64, error) {
+	var total, packs int64
+	objects := filepath.Join(repoPath, ".git", "objects")
+	err := filepath.WalkDir(objects, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil || entry.IsDir() {
+			return err
+		}
+		info, err := entry.Info()
+		if err != nil {
+			return err
+		}
+		total += info.Size()
+		if strings.HasSuffix(path, ".pack") {
+			packs += info.Size()
+		}
+		return nil
+	})
+	return total, packs, err
+}
+
+// ObjectCount returns the number of objects of a repository, or the context error when it is cancelled
+func ObjectCount(ctx context.Context, repo *git.Repository) (int, error) {
+	objects, err := repo.Storer.IterEncodedObjects(plumbing.AnyObject)
+	if err != nil {
+		return 0, err
+	}
+	count := 0
+	err = objects.ForEach(func(plumbing.EncodedObject) error {
+		count++
+		return ctx.Err()
+	})
+	return count, err
+}
+
+// TreeFiles returns the files of a commit, the largest first, or the context error when it is cancelled
+func TreeFiles(ctx context.Context, repo *git.Repository, hash plumbing.Hash) ([]Blob, error) {
+	commit, err := repo.CommitObject(hash)
+	if err != nil {
+		return nil, err
+	}
+	files, err := commit.Files()
+	if err != nil {
+		return nil, err
+	}
+
+	var blobs []Blob
+	err = files.ForEach(func(file *object.File) error {
+		blobs = append(blobs, Blob{Hash: file.Hash, Path: file.Name, Size: file.Size})
+		return ctx.Err()
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.SliceStable(blobs, func(i, j int) bool { return blobs[i].Size > blobs[j].Size })
+	return blobs, nil
+}
+
+// LargestBlobs returns the largest blobs of at least minSize bytes found anywhere in the history of
+// a repository, the largest first, with their path and the commit that introduced them. A non-zero
+// before date ignores the commits made after it. Blobs that no commit references are left out.
+func LargestBlobs(ctx context.Context, repo *git.Repository, minSize int64, limit int, before time.Time) ([]Blob, error) {
+	// Only the blobs large enough to be listed are located in the history, so that the walk stops
+	// as soon as all of them are found. They are all located before the largest are kept, so that
+	// the blobs added after the before date or referenced by no commit do not take their place.
+	blobIter, err := repo.BlobObjects()
+	if err != nil {
+		return nil, err
+	}
+	var candidates []Blob
+	err = blobIter.ForEach(func(blob *object.Blob) error {
+		if blob.Size >= minSize {
+			candidates = append(candidates, Blob{Hash: blob.Hash, Size: blob.Size})
+		}
+		return nil
+	})
+	if err != nil || len(candidates) == 0 {
+		return nil, err
+	}
+	wanted := make(map[plumbing.Hash]int)
+	for i, candidate := range candidates {
+		wanted[candidate.Hash] = i
+	}
+
+	// The log starts with the most recent commit: it is walked backwards, so that the first commit
+	// adding a blob is the one that introduced it
+	commitIter, err := repo.Log(&git.LogOptions{All: true, Order: git.LogOrderCommitterTime})
+	if err != nil {
+		return nil, err
+	}
+	var commits []plumbing.Hash
+	err = commitIter.ForEach(func(c *object.Commit) error {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if before.IsZero() || c.Committer.When.Before(before) {
+			commits = append(commits, c.Hash)
+		}
+		return nil
+	})
+	commitIter.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	located := 0
+	for i := len(commits) - 1; i >= 0 && located < len(candidates); i-- {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		c, err := repo.CommitObject(commits[i])
+		if err != nil {
+			return nil, err
+		}
+		tree, err := c.Tree()
+		if err != nil {
+			return nil, err
+		}
+		var parentTree *object.Tree
+		if c.NumParents() > 0 {
+			// The parent of the first commit of a shallow clone is missing: the commit is then
+			// compared with an empty tree
+			parent, err := c.Parent(0)
+			if err != nil && err != plumbing.ErrObjectNotFound {
+				return nil, err
+			}
+			if parent != nil {
+				if parentTree, err = parent.Tree(); err != nil {
+					return nil, err
+				}
+			}
+		}
+		changes, err := object.DiffTreeContext(ctx, parentTree, tree)
+		if err != nil {
+			return nil, err
+		}
+		for _, change := range changes {
+			j, ok := wanted[change.To.TreeEntry.Hash]
+			if !ok || change.To.Name == "" || candidates[j].Path != "" {
+				continue
+			}
+			candidates[j].Path = change.To.Name
+			candidates[j].Commit = c.Hash
+			candidates[j].Date = c.Committer.When
+			located++
+		}
+	}
+
+	var blobs []Blob
+	for _, candidate := range candidates {
+		if candidate.Path != "" {
+			blobs = append(blobs, candidate)
+		}
+	}
+	sort.SliceStable(blobs, func(i, j int) bool { return blobs[i].Size > blobs[j].Size })
+	if len(blobs) > limit {
+		blobs = blobs[:limit]
+	}
+	return blobs, nil
+}
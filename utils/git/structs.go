@@ -1,6 +1,10 @@
 package gitUtils
 
-import "time"
+import (
+	"time"
+
+	"github.com/go-git/go-git/v5/plumbing"
+)
 
 type Commit struct {
 	Hash    string    `json:"hash"`
@@ -9,3 +13,20 @@ type Commit struct {
 	Date    time.Time `json:"date"`
 	Message string    `json:"message"`
 }
+
+// Tag is a tag of a repository, resolved to the commit it points to
+type Tag struct {
+	Name   string
+	Commit plumbing.Hash
+	// Date is the tagger date of an annotated tag, or the commit date of a lightweight tag
+	Date time.Time
+}
+
+// Blob is a file of a repository, with the commit that introduced it when it is known
+type Blob struct {
+	Hash   plumbing.Hash
+	Path   string
+	Size   int64
+	Commit plumbing.Hash
+	Date   time.Time
+}
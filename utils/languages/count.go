@@ -0,0 +1,165 @@
+package languages
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/s3pweb/gitArchiveS3Report/utils/structs"
+)
+
+// headerSize is the number of bytes inspected for binary content and generated markers
+const headerSize = 8000
+
+// CountFile counts the code, comment and blank lines of a single file.
+// The boolean is false when the file is not a known language, is binary or is generated.
+func CountFile(path string) (string, structs.LanguageStats, bool) {
+	language, ok := Detect(path)
+	if !ok {
+		return "", structs.LanguageStats{}, false
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return "", structs.LanguageStats{}, false
+	}
+
+	head := content
+	if len(head) > headerSize {
+		head = head[:headerSize]
+	}
+	if bytes.IndexByte(head, 0) != -1 || IsGenerated(path, head) {
+		return "", structs.LanguageStats{}, false
+	}
+
+	return language.Name, countLines(language, content), true
+}
+
+// countLines classifies every line of the content as code, comment or blank
+func countLines(language Language, content []byte) structs.LanguageStats {
+	stats := structs.LanguageStats{Files: 1}
+	inBlock := false
+
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	scanner.Buffer(make([]byte, 0, 64*1024), 10*1024*1024)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+
+		switch {
+		case line == "" && !inBlock:
+			stats.Blank++
+		case inBlock:
+			stats.Comment++
+			if strings.Contains(line, language.BlockEnd) {
+				inBlock = false
+			}
+		case language.BlockStart != "" && strings.HasPrefix(line, language.BlockStart):
+			stats.Comment++
+			rest := strings.TrimPrefix(line, language.BlockStart)
+			inBlock = !strings.Contains(rest, language.BlockEnd)
+		case hasAnyPrefix(line, language.LineComments):
+			stats.Comment++
+		default:
+			stats.Code++
+		}
+	}
+
+	return stats
+}
+
+func hasAnyPrefix(line string, prefixes []string) bool {
+	for _, prefix := range prefixes {
+		if strings.HasPrefix(line, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// Analyze walks a repository and returns line counts per language, with the lines of the test files.
+// Vendored directories, binary files and generated files are skipped. The walk stops when the context
+// is cancelled.
+func Analyze(ctx context.Context, repoPath string) (map[string]structs.LanguageStats, error) {
+	result := make(map[string]structs.LanguageStats)
+
+	err := filepath.WalkDir(repoPath, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if path != repoPath && IsVendoredDir(d.Name()) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+
+		name, stats, ok := CountFile(path)
+		if !ok {
+			return nil
+		}
+		if relPath, err := filepath.Rel(repoPath, path); err == nil && IsTestFile(filepath.ToSlash(relPath)) {
+			stats.TestFiles = stats.Files
+			stats.TestCode = stats.Code
+		}
+		result[name] = result[name].Add(stats)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// Dominant returns the programming language with the most code lines and the
+// total number of code lines across all languages
+func Dominant(stats map[string]structs.LanguageStats) (string, int) {
+	var dominant string
+	var dominantCode, fallbackCode, total int
+	var fallback string
+
+	for name, stat := range stats {
+		total += stat.Code
+
+		language := languageByName(name)
+		if language.Programming {
+			if stat.Code > dominantCode || (stat.Code == dominantCode && name < dominant) {
+				dominant = name
+				dominantCode = stat.Code
+			}
+		} else if stat.Code > fallbackCode || (stat.Code == fallbackCode && name < fallback) {
+			fallback = name
+			fallbackCode = stat.Code
+		}
+	}
+
+	if dominant == "" {
+		dominant = fallback
+	}
+	return dominant, total
+}
+
+// languageByName looks up a language definition from its display name
+func languageByName(name string) Language {
+	for _, language := range languagesByExtension {
+		if language.Name == name {
+			return language
+		}
+	}
+	for _, language := range languagesByFileName {
+		if language.Name == name {
+			return language
+		}
+	}
+	return Language{Name: name}
+}
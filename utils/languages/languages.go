@@ -0,0 +1,149 @@
+package languages
+
+import (
+	"path/filepath"
+	"strings"
+)
+
+// Language describes a language and the syntax used to recognise its comments
+type Language struct {
+	Name         string
+	LineComments []string
+	BlockStart   string
+	BlockEnd     string
+	// Programming is false for data and markup formats, which are counted
+	// but never reported as the dominant language of a repository
+	Programming bool
+}
+
+var (
+	cStyle    = []string{"//"}
+	hashStyle = []string{"#"}
+)
+
+// languagesByExtension maps lowercase file extensions to languages
+var languagesByExtension = map[string]Language{
+	".go":     {Name: "Go", LineComments: cStyle, BlockStart: "/*", BlockEnd: "*/", Programming: true},
+	".js":     {Name: "JavaScript", LineComments: cStyle, BlockStart: "/*", BlockEnd: "*/", Programming: true},
+	".mjs":    {Name: "JavaScript", LineComments: cStyle, BlockStart: "/*", BlockEnd: "*/", Programming: true},
+	".cjs":    {Name: "JavaScript", LineComments: cStyle, BlockStart: "/*", BlockEnd: "*/", Programming: true},
+	".jsx":    {Name: "JavaScript", LineComments: cStyle, BlockStart: "/*", BlockEnd: "*/", Programming: true},
+	".ts":     {Name: "TypeScript", LineComments: cStyle, BlockStart: "/*", BlockEnd: "*/", Programming: true},
+	".tsx":    {Name: "TypeScript", LineComments: cStyle, BlockStart: "/*", BlockEnd: "*/", Programming: true},
+	".java":   {Name: "Java", LineComments: cStyle, BlockStart: "/*", BlockEnd: "*/", Programming: true},
+	".kt":     {Name: "Kotlin", LineComments: cStyle, BlockStart: "/*", BlockEnd: "*/", Programming: true},
+	".scala":  {Name: "Scala", LineComments: cStyle, BlockStart: "/*", BlockEnd: "*/", Programming: true},
+	".groovy": {Name: "Groovy", LineComments: cStyle, BlockStart: "/*", BlockEnd: "*/", Programming: true},
+	".c":      {Name: "C", LineComments: cStyle, BlockStart: "/*", BlockEnd: "*/", Programming: true},
+	".h":      {Name: "C", LineComments: cStyle, BlockStart: "/*", BlockEnd: "*/", Programming: true},
+	".cpp":    {Name: "C++", LineComments: cStyle, BlockStart: "/*", BlockEnd: "*/", Programming: true},
+	".cc":     {Name: "C++", LineComments: cStyle, BlockStart: "/*", BlockEnd: "*/", Programming: true},
+	".hpp":    {Name: "C++", LineComments: cStyle, BlockStart: "/*", BlockEnd: "*/", Programming: true},
+	".cs":     {Name: "C#", LineComments: cStyle, BlockStart: "/*", BlockEnd: "*/", Programming: true},
+	".swift":  {Name: "Swift", LineComments: cStyle, BlockStart: "/*", BlockEnd: "*/", Programming: true},
+	".rs":     {Name: "Rust", LineComments: cStyle, BlockStart: "/*", BlockEnd: "*/", Programming: true},
+	".dart":   {Name: "Dart", LineComments: cStyle, BlockStart: "/*", BlockEnd: "*/", Programming: true},
+	".php":    {Name: "PHP", LineComments: []string{"//", "#"}, BlockStart: "/*", BlockEnd: "*/", Programming: true},
+	".py":     {Name: "Python", LineComments: hashStyle, Programming: true},
+	".rb":     {Name: "Ruby", LineComments: hashStyle, BlockStart: "=begin", BlockEnd: "=end", Programming: true},
+	".pl":     {Name: "Perl", LineComments: hashStyle, Programming: true},
+	".sh":     {Name: "Shell", LineComments: hashStyle, Programming: true},
+	".bash":   {Name: "Shell", LineComments: hashStyle, Programming: true},
+	".ps1":    {Name: "PowerShell", LineComments: hashStyle, BlockStart: "<#", BlockEnd: "#>", Programming: true},
+	".lua":    {Name: "Lua", LineComments: []string{"--"}, BlockStart: "--[[", BlockEnd: "]]", Programming: true},
+	".sql":    {Name: "SQL", LineComments: []string{"--"}, BlockStart: "/*", BlockEnd: "*/", Programming: true},
+	".r":      {Name: "R", LineComments: hashStyle, Programming: true},
+	".vue":    {Name: "Vue", LineComments: cStyle, BlockStart: "<!--", BlockEnd: "-->", Programming: true},
+	".html":   {Name: "HTML", BlockStart: "<!--", BlockEnd: "-->"},
+	".htm":    {Name: "HTML", BlockStart: "<!--", BlockEnd: "-->"},
+	".css":    {Name: "CSS", BlockStart: "/*", BlockEnd: "*/"},
+	".scss":   {Name: "SCSS", LineComments: cStyle, BlockStart: "/*", BlockEnd: "*/"},
+	".less":   {Name: "LESS", LineComments: cStyle, BlockStart: "/*", BlockEnd: "*/"},
+	".xml":    {Name: "XML", BlockStart: "<!--", BlockEnd: "-->"},
+	".json":   {Name: "JSON"},
+	".yaml":   {Name: "YAML", LineComments: hashStyle},
+	".yml":    {Name: "YAML", LineComments: hashStyle},
+	".toml":   {Name: "TOML", LineComments: hashStyle},
+	".tf":     {Name: "Terraform", LineComments: []string{"#", "//"}, BlockStart: "/*", BlockEnd: "*/", Programming: true},
+	".md":     {Name: "Markdown"},
+}
+
+// languagesByFileName maps lowercase file names without a meaningful extension to languages
+var languagesByFileName = map[string]Language{
+	"dockerfile":  {Name: "Dockerfile", LineComments: hashStyle},
+	"makefile":    {Name: "Makefile", LineComments: hashStyle},
+	"jenkinsfile": {Name: "Groovy", LineComments: cStyle, BlockStart: "/*", BlockEnd: "*/", Programming: true},
+}
+
+// vendoredDirectories are directory names whose content is third-party or build output
+var vendoredDirectories = map[string]bool{
+	".git":             true,
+	"vendor":           true,
+	"node_modules":     true,
+	"bower_components": true,
+	"third_party":      true,
+	"dist":             true,
+	"target":           true,
+	".idea":            true,
+	".vscode":          true,
+}
+
+// generatedFileNames are lockfiles and other files that are never written by hand
+var generatedFileNames = map[string]bool{
+	"package-lock.json": true,
+	"yarn.lock":         true,
+	"pnpm-lock.yaml":    true,
+	"composer.lock":     true,
+	"go.sum":            true,
+	"poetry.lock":       true,
+	"gemfile.lock":      true,
+}
+
+// Detect returns the language of a file based on its name
+func Detect(fileName string) (Language, bool) {
+	base := strings.ToLower(filepath.Base(fileName))
+	if language, ok := languagesByFileName[base]; ok {
+		return language, true
+	}
+	if strings.HasPrefix(base, "dockerfile") {
+		return languagesByFileName["dockerfile"], true
+	}
+	language, ok := languagesByExtension[filepath.Ext(base)]
+	return language, ok
+}
+
+// IsVendoredDir reports whether a directory name holds third-party or build output
+func IsVendoredDir(name string) bool {
+	return vendoredDirectories[strings.ToLower(name)]
+}
+
+// IsVendored reports whether a slash-separated path relative to the repository
+// root lives inside a vendored directory
+func IsVendored(relPath string) bool {
+	for _, part := range strings.Split(filepath.ToSlash(relPath), "/") {
+		if IsVendoredDir(part) {
+			return true
+		}
+	}
+	return false
+}
+
+// IsGenerated reports whether a file is generated, either from its name or from
+// the marker comment that code generators put at the top of the file
+func IsGenerated(fileName string, head []byte) bool {
+	base := strings.ToLower(filepath.Base(fileName))
+	if generatedFileNames[base] {
+		return true
+	}
+	if strings.HasSuffix(base, ".min.js") || strings.HasSuffix(base, ".min.css") ||
+		strings.HasSuffix(base, ".pb.go") || strings.Contains(base, "_generated.") ||
+		strings.HasSuffix(base, ".designer.cs") {
+		return true
+	}
+
+	header := string(head)
+	if strings.Contains(header, "@generated") {
+		return true
+	}
+	return strings.Contains(header, "Code generated") && strings.Contains(header, "DO NOT EDIT")
+}
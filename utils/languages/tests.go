@@ -0,0 +1,72 @@
+package languages
+
+import (
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/s3pweb/gitArchiveS3Report/utils/structs"
+)
+
+// testFileRegexes match the names of test files by the conventions of each language
+var testFileRegexes = []*regexp.Regexp{
+	regexp.MustCompile(`_test\.(go|py|rb|dart)$`),                                    // Go, Python, Ruby, Dart
+	regexp.MustCompile(`\.(test|spec|e2e-spec|cy)\.(js|jsx|mjs|cjs|ts|tsx|vue)$`),    // JavaScript and TypeScript
+	regexp.MustCompile(`^test_.+\.py$`),                                              // pytest
+	regexp.MustCompile(`^conftest\.py$`),                                             // pytest fixtures
+	regexp.MustCompile(`_spec\.rb$`),                                                 // RSpec
+	regexp.MustCompile(`(Test|Tests|IT|Spec)\.(java|kt|scala|groovy|cs|php|swift)$`), // JUnit, xUnit, PHPUnit, XCTest
+}
+
+// testDirectories are the directory names that only hold tests, e.g. src/test/java in Maven,
+// __tests__ in Jest or tests/ in Rust, Python and PHP
+var testDirectories = map[string]bool{
+	"test":      true,
+	"tests":     true,
+	"__tests__": true,
+	"spec":      true,
+	"specs":     true,
+	"e2e":       true,
+	"testing":   true,
+}
+
+// IsTestFile reports whether a slash-separated path relative to the repository root is a test
+// of a programming language, from its name or from a directory of tests in its path
+func IsTestFile(relPath string) bool {
+	language, ok := Detect(relPath)
+	if !ok || !language.Programming {
+		return false
+	}
+
+	name := path.Base(relPath)
+	for _, regex := range testFileRegexes {
+		if regex.MatchString(name) {
+			return true
+		}
+	}
+	dirs := strings.Split(path.Dir(relPath), "/")
+	for _, dir := range dirs {
+		if testDirectories[strings.ToLower(dir)] || strings.HasSuffix(dir, ".Tests") {
+			return true
+		}
+	}
+	return false
+}
+
+// Tests returns the number of test files and test code lines across the programming languages,
+// and the ratio of the test code lines to the other code lines, as a percentage
+func Tests(stats map[string]structs.LanguageStats) (int, int, float64) {
+	var files, testCode, code int
+	for name, stat := range stats {
+		if !languageByName(name).Programming {
+			continue
+		}
+		files += stat.TestFiles
+		testCode += stat.TestCode
+		code += stat.Code - stat.TestCode
+	}
+	if code == 0 {
+		return files, testCode, 0
+	}
+	return files, testCode, float64(testCode) / float64(code) * 100
+}
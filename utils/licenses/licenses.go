@@ -0,0 +1,263 @@
+package licenses
+
+import (
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/s3pweb/gitArchiveS3Report/utils/languages"
+	"github.com/s3pweb/gitArchiveS3Report/utils/structs"
+)
+
+// Classes of the licenses that are not open source licenses
+const (
+	Proprietary = "proprietary"
+	Unknown     = "unknown"
+)
+
+// minConfidence is the confidence below which a license text is classified as unknown
+const minConfidence = 50
+
+// maxHeaderFiles is the number of source files whose header is read for an SPDX identifier
+const maxHeaderFiles = 500
+
+var (
+	// licenseFileRegex matches LICENSE, LICENSE.md, LICENCE-MIT, COPYING, UNLICENSE, ...
+	licenseFileRegex = regexp.MustCompile(`(?i)^(licen[cs]e|copying|unlicense)([.\-_].*)?$`)
+
+	// spdxRegex matches the SPDX identifier of a file, e.g. "SPDX-License-Identifier: Apache-2.0"
+	spdxRegex = regexp.MustCompile(`SPDX-License-Identifier:\s*([A-Za-z0-9.+\-]+(?:\s+(?:OR|AND|WITH)\s+[A-Za-z0-9.+\-]+)*)`)
+
+	spaceRegex = regexp.MustCompile(`\s+`)
+)
+
+// license describes a license by the phrases of its text. A text containing one of the
+// absent phrases belongs to a close relative of the license, e.g. LGPL for GPL.
+type license struct {
+	id      string
+	phrases []string
+	absent  []string
+}
+
+// knownLicenses are the licenses recognised from their text. Proprietary is only
+// considered when no open source license is recognised.
+var knownLicenses = []license{
+	{id: "MIT", phrases: []string{
+		"permission is hereby granted, free of charge",
+		"the above copyright notice and this permission notice shall be included",
+		"without restriction, including without limitation the rights to use",
+		`the software is provided "as is"`,
+	}},
+	{id: "Apache-2.0", phrases: []string{
+		"apache license",
+		"version 2.0",
+		"www.apache.org/licenses",
+		"grant of patent license",
+	}},
+	{id: "GPL-3.0", phrases: []string{
+		"gnu general public license",
+		"version 3, 29 june 2007",
+		"everyone is permitted to copy and distribute verbatim copies",
+	}, absent: []string{"gnu lesser general public license", "gnu affero general public license"}},
+	{id: "GPL-2.0", phrases: []string{
+		"gnu general public license",
+		"version 2, june 1991",
+		"everyone is permitted to copy and distribute verbatim copies",
+	}, absent: []string{"gnu lesser general public license", "gnu library general public license"}},
+	{id: "LGPL-3.0", phrases: []string{
+		"gnu lesser general public license",
+		"version 3, 29 june 2007",
+	}},
+	{id: "AGPL-3.0", phrases: []string{
+		"gnu affero general public license",
+		"version 3, 19 november 2007",
+	}},
+	{id: "MPL-2.0", phrases: []string{
+		"mozilla public license",
+		"version 2.0",
+	}},
+	{id: "BSD-3-Clause", phrases: []string{
+		"redistribution and use in source and binary forms",
+		"neither the name of",
+		"this software is provided by the copyright holders and contributors",
+	}},
+	{id: "BSD-2-Clause", phrases: []string{
+		"redistribution and use in source and binary forms",
+		"this software is provided by the copyright holders and contributors",
+	}, absent: []string{"neither the name of"}},
+	{id: "ISC", phrases: []string{
+		"permission to use, copy, modify, and/or distribute this software for any purpose",
+		"with or without fee is hereby granted",
+	}},
+	{id: "Unlicense", phrases: []string{
+		"this is free and unencumbered software released into the public domain",
+	}},
+}
+
+// proprietaryLicense is recognised from the usual phrases of closed source notices
+var proprietaryLicense = license{id: Proprietary, phrases: []string{
+	"all rights reserved",
+	"proprietary",
+	"confidential",
+	"unauthorized copying",
+}}
+
+// Detect finds the license of a repository: from the license files at its root, classified from
+// their text, or else from the SPDX identifiers of the headers of its source files.
+// The license is empty when the repository has neither.
+func Detect(repoPath string) (structs.LicenseInfo, error) {
+	entries, err := os.ReadDir(repoPath)
+	if err != nil {
+		return structs.LicenseInfo{}, err
+	}
+
+	var best structs.LicenseInfo
+	for _, entry := range entries {
+		if entry.IsDir() || !licenseFileRegex.MatchString(entry.Name()) {
+			continue
+		}
+		content, err := os.ReadFile(filepath.Join(repoPath, entry.Name()))
+		if err != nil {
+			continue
+		}
+		id, confidence := Classify(string(content))
+		if best.File == "" || confidence > best.Confidence {
+			best = structs.LicenseInfo{ID: id, Confidence: confidence, File: entry.Name(), Source: structs.LicenseSourceFile}
+		}
+	}
+	if best.File != "" {
+		return best, nil
+	}
+	return fromHeaders(repoPath)
+}
+
+// Classify recognises a license from its text and returns its identifier, with the percentage of
+// the phrases of the license found in the text as confidence. An SPDX identifier in the text is
+// trusted fully. A text that matches no license well enough is unknown, with the confidence of
+// the closest license, so that a low confidence tells that the text is far from any license.
+func Classify(text string) (string, float64) {
+	if match := spdxRegex.FindStringSubmatch(text); match != nil {
+		return Normalize(match[1]), 100
+	}
+
+	normalized := spaceRegex.ReplaceAllString(strings.ToLower(text), " ")
+	normalized = strings.NewReplacer("“", `"`, "”", `"`).Replace(normalized)
+
+	bestID, bestConfidence := Unknown, 0.0
+	for _, candidate := range knownLicenses {
+		if confidence := score(candidate, normalized); confidence > bestConfidence {
+			bestID, bestConfidence = candidate.id, confidence
+		}
+	}
+	if bestConfidence >= minConfidence {
+		return bestID, bestConfidence
+	}
+	if confidence := score(proprietaryLicense, normalized); confidence >= minConfidence {
+		return Proprietary, confidence
+	}
+	return Unknown, bestConfidence
+}
+
+// score returns the percentage of the phrases of a license found in a normalized text
+func score(candidate license, text string) float64 {
+	for _, phrase := range candidate.absent {
+		if strings.Contains(text, phrase) {
+			return 0
+		}
+	}
+	found := 0
+	for _, phrase := range candidate.phrases {
+		if strings.Contains(text, phrase) {
+			found++
+		}
+	}
+	return float64(found) / float64(len(candidate.phrases)) * 100
+}
+
+// Normalize drops the "-only" and "-or-later" suffixes of GNU identifiers, so that
+// "GPL-3.0-only" and "GPL-3.0-or-later" are both GPL-3.0
+func Normalize(id string) string {
+	id = strings.TrimSpace(id)
+	id = strings.TrimSuffix(id, "-only")
+	id = strings.TrimSuffix(id, "-or-later")
+	return strings.TrimSuffix(id, "+")
+}
+
+// IsApproved reports whether a license is one of the approved licenses, ignoring the case.
+// Without any approved license, every recognised license is approved.
+func IsApproved(id string, approved []string) bool {
+	if id == "" || id == Unknown {
+		return false
+	}
+	if len(approved) == 0 {
+		return true
+	}
+	for _, candidate := range approved {
+		if strings.EqualFold(Normalize(candidate), Normalize(id)) {
+			return true
+		}
+	}
+	return false
+}
+
+// fromHeaders reads the SPDX identifiers at the top of the source files of a repository and
+// returns the most common one, with the share of the headers agreeing with it as confidence
+func fromHeaders(repoPath string) (structs.LicenseInfo, error) {
+	counts := make(map[string]int)
+	total, scanned := 0, 0
+
+	err := filepath.WalkDir(repoPath, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if path != repoPath && (d.Name() == ".git" || languages.IsVendoredDir(d.Name())) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if scanned >= maxHeaderFiles {
+			return filepath.SkipAll
+		}
+		if _, known := languages.Detect(d.Name()); !known || !d.Type().IsRegular() {
+			return nil
+		}
+		scanned++
+
+		file, err := os.Open(path)
+		if err != nil {
+			return nil
+		}
+		defer file.Close()
+		head := make([]byte, 2048)
+		n, _ := io.ReadFull(file, head)
+		if match := spdxRegex.FindSubmatch(head[:n]); match != nil {
+			counts[Normalize(string(match[1]))]++
+			total++
+		}
+		return nil
+	})
+	if err != nil || total == 0 {
+		return structs.LicenseInfo{}, err
+	}
+
+	ids := make([]string, 0, len(counts))
+	for id := range counts {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool {
+		if counts[ids[i]] != counts[ids[j]] {
+			return counts[ids[i]] > counts[ids[j]]
+		}
+		return ids[i] < ids[j]
+	})
+	return structs.LicenseInfo{
+		ID:         ids[0],
+		Confidence: float64(counts[ids[0]]) / float64(total) * 100,
+		Source:     structs.LicenseSourceHeaders,
+	}, nil
+}
@@ -0,0 +1,194 @@
+package pipelines
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/s3pweb/gitArchiveS3Report/utils/structs"
+	"gopkg.in/yaml.v3"
+)
+
+// FileName is the name of the Bitbucket Pipelines configuration, always at the repository root
+const FileName = "bitbucket-pipelines.yml"
+
+var (
+	testRegex  = regexp.MustCompile(`(?i)(\btests?\b|jest|mocha|pytest|phpunit|karma|cypress|vitest|\bverify\b)`)
+	lintRegex  = regexp.MustCompile(`(?i)(lint|tslint|golangci|flake8|pylint|ruff|prettier|checkstyle|stylelint)`)
+	sonarRegex = regexp.MustCompile(`(?i)sonar`)
+)
+
+// pipelineSections are the sections of the "pipelines" key that hold named pipelines
+var pipelineSections = []string{"branches", "pull-requests", "tags", "custom"}
+
+// Analyze reads the bitbucket-pipelines.yml file at the root of a repository and reports
+// its pipelines, images, deployments, pipes and the quality steps it runs
+func Analyze(repoPath string) structs.PipelineInfo {
+	var info structs.PipelineInfo
+
+	content, err := os.ReadFile(filepath.Join(repoPath, FileName))
+	if err != nil {
+		info.Gaps = []string{"no " + FileName}
+		return info
+	}
+	info.Present = true
+
+	var document map[string]interface{}
+	if err := yaml.Unmarshal(content, &document); err != nil {
+		info.ParseError = err.Error()
+		info.Gaps = []string{fmt.Sprintf("%s cannot be parsed: %v", FileName, err)}
+		return info
+	}
+
+	a := &analyzer{info: &info, seen: make(map[string]bool)}
+	a.addImage(document["image"])
+
+	definitions, _ := document["pipelines"].(map[string]interface{})
+	if items, ok := definitions["default"].([]interface{}); ok {
+		info.Pipelines = append(info.Pipelines, "default")
+		a.walkItems(items)
+	}
+	for _, section := range pipelineSections {
+		named, ok := definitions[section].(map[string]interface{})
+		if !ok {
+			continue
+		}
+		var names []string
+		for name := range named {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			info.Pipelines = append(info.Pipelines, section+": "+name)
+			if items, ok := named[name].([]interface{}); ok {
+				a.walkItems(items)
+			}
+		}
+	}
+
+	info.Gaps = gaps(info)
+	return info
+}
+
+// analyzer accumulates what is found while walking the steps of the pipelines
+type analyzer struct {
+	info *structs.PipelineInfo
+	seen map[string]bool
+}
+
+// walkItems walks a list of pipeline items: steps, parallel groups and stages
+func (a *analyzer) walkItems(items []interface{}) {
+	for _, item := range items {
+		entry, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if step, ok := entry["step"].(map[string]interface{}); ok {
+			a.walkStep(step)
+		}
+		// parallel is either a list of items or a map with a "steps" list
+		switch parallel := entry["parallel"].(type) {
+		case []interface{}:
+			a.walkItems(parallel)
+		case map[string]interface{}:
+			if steps, ok := parallel["steps"].([]interface{}); ok {
+				a.walkItems(steps)
+			}
+		}
+		if stage, ok := entry["stage"].(map[string]interface{}); ok {
+			if deployment, ok := stage["deployment"].(string); ok {
+				a.add(&a.info.Deployments, "deployment:"+deployment, deployment)
+			}
+			if steps, ok := stage["steps"].([]interface{}); ok {
+				a.walkItems(steps)
+			}
+		}
+	}
+}
+
+// walkStep records the image, deployment, pipes and quality checks of a step
+func (a *analyzer) walkStep(step map[string]interface{}) {
+	a.addImage(step["image"])
+
+	if deployment, ok := step["deployment"].(string); ok {
+		a.add(&a.info.Deployments, "deployment:"+deployment, deployment)
+	}
+
+	name, _ := step["name"].(string)
+	a.check(name)
+
+	for _, key := range []string{"script", "after-script"} {
+		commands, _ := step[key].([]interface{})
+		for _, command := range commands {
+			switch value := command.(type) {
+			case string:
+				a.check(value)
+			case map[string]interface{}:
+				if pipe, ok := value["pipe"].(string); ok {
+					a.add(&a.info.Pipes, "pipe:"+pipe, pipe)
+					a.check(pipe)
+				}
+			}
+		}
+	}
+}
+
+// check flags the quality steps mentioned by a step name, a script line or a pipe
+func (a *analyzer) check(text string) {
+	if testRegex.MatchString(text) {
+		a.info.HasTests = true
+	}
+	if lintRegex.MatchString(text) {
+		a.info.HasLint = true
+	}
+	if sonarRegex.MatchString(text) {
+		a.info.HasSonar = true
+	}
+}
+
+// addImage records an image given either as a string or as a map with a "name" key
+func (a *analyzer) addImage(image interface{}) {
+	switch value := image.(type) {
+	case string:
+		a.add(&a.info.Images, "image:"+value, value)
+	case map[string]interface{}:
+		if name, ok := value["name"].(string); ok {
+			a.add(&a.info.Images, "image:"+name, name)
+		}
+	}
+}
+
+// add appends a value to a list once
+func (a *analyzer) add(list *[]string, key, value string) {
+	if value == "" || a.seen[key] {
+		return
+	}
+	a.seen[key] = true
+	*list = append(*list, value)
+}
+
+// gaps lists what a parsed pipeline configuration is missing
+func gaps(info structs.PipelineInfo) []string {
+	var result []string
+	if len(info.Pipelines) == 0 {
+		result = append(result, "no pipeline defined")
+	}
+	if !info.HasTests {
+		result = append(result, "no test step")
+	}
+	if !info.HasLint {
+		result = append(result, "no lint step")
+	}
+	if !info.HasSonar {
+		result = append(result, "no Sonar analysis")
+	}
+	for _, pipe := range info.Pipes {
+		if !strings.Contains(pipe, ":") {
+			result = append(result, fmt.Sprintf("pipe %s is not pinned to a version", pipe))
+		}
+	}
+	return result
+}
@@ -0,0 +1,360 @@
+package rules
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/go-git/go-billy/v5/osfs"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing/format/gitignore"
+	"github.com/s3pweb/gitArchiveS3Report/utils/languages"
+	"github.com/s3pweb/gitArchiveS3Report/utils/licenses"
+	"github.com/s3pweb/gitArchiveS3Report/utils/structs"
+	"gopkg.in/yaml.v3"
+)
+
+// maxCachedSize is the size above which file contents are read again instead of being kept in memory
+const maxCachedSize = 1 << 20
+
+// snapshot is the list of files of a branch, read once and shared by every rule
+type snapshot struct {
+	root     string
+	files    []string
+	contents map[string]string
+	regexes  map[string]*regexp.Regexp
+	// license is the license of the branch, detected on the first license rule when not given
+	license *structs.LicenseInfo
+}
+
+// newSnapshot lists the files of a repository, relative to its root and slash-separated.
+// Vendored directories, the files matching one of the exclude globs and the files ignored
+// by .gitignore (unless they are tracked anyway) are left out. The tracked files are the
+// paths of the analyzed commit; when they are unknown, the index of the repository is read.
+func newSnapshot(root string, trackedPaths []string, excludes []string) (*snapshot, error) {
+	s := &snapshot{
+		root:     root,
+		contents: make(map[string]string),
+		regexes:  make(map[string]*regexp.Regexp),
+	}
+
+	var ignored gitignore.Matcher
+	if patterns, err := gitignore.ReadPatterns(osfs.New(root), nil); err == nil && len(patterns) > 0 {
+		ignored = gitignore.NewMatcher(patterns)
+	}
+	tracked := make(map[string]bool)
+	for _, trackedPath := range trackedPaths {
+		tracked[trackedPath] = true
+	}
+	if trackedPaths == nil {
+		tracked = trackedFiles(root)
+	}
+
+	err := filepath.WalkDir(root, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if p == root {
+			return nil
+		}
+		relPath, err := filepath.Rel(root, p)
+		if err != nil {
+			return err
+		}
+		relPath = filepath.ToSlash(relPath)
+
+		if d.IsDir() {
+			if d.Name() == ".git" || languages.IsVendoredDir(d.Name()) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		for _, exclude := range excludes {
+			if MatchGlob(exclude, relPath) {
+				return nil
+			}
+		}
+		if ignored != nil && !tracked[relPath] && ignored.Match(strings.Split(relPath, "/"), false) {
+			return nil
+		}
+
+		s.files = append(s.files, relPath)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// trackedFiles returns the paths of the index of a repository, or nothing when the directory is not a repository
+func trackedFiles(root string) map[string]bool {
+	tracked := make(map[string]bool)
+	repo, err := git.PlainOpen(root)
+	if err != nil {
+		return tracked
+	}
+	index, err := repo.Storer.Index()
+	if err != nil {
+		return tracked
+	}
+	for _, entry := range index.Entries {
+		tracked[entry.Name] = true
+	}
+	return tracked
+}
+
+// regex compiles a pattern once per snapshot. Invalid patterns match nothing.
+func (s *snapshot) regex(pattern string) *regexp.Regexp {
+	if regex, ok := s.regexes[pattern]; ok {
+		return regex
+	}
+	regex, err := regexp.Compile(pattern)
+	if err != nil {
+		fmt.Printf("Error compiling regex: %v\n", err)
+	}
+	s.regexes[pattern] = regex
+	return regex
+}
+
+// content reads a file once per snapshot
+func (s *snapshot) content(relPath string) string {
+	if content, ok := s.contents[relPath]; ok {
+		return content
+	}
+	data, err := os.ReadFile(filepath.Join(s.root, filepath.FromSlash(relPath)))
+	if err != nil {
+		data = nil
+	}
+	if len(data) <= maxCachedSize {
+		s.contents[relPath] = string(data)
+	}
+	return string(data)
+}
+
+// filesNamed returns the files in the scope of a rule whose base name matches the pattern
+func (s *snapshot) filesNamed(rule Rule, pattern string) []string {
+	regex := s.regex(pattern)
+	if regex == nil {
+		return nil
+	}
+	var matches []string
+	for _, file := range s.files {
+		if inScope(rule, file) && regex.MatchString(path.Base(file)) {
+			matches = append(matches, file)
+		}
+	}
+	return matches
+}
+
+// inScope reports whether a file is in the scope of a rule: at the root for the root scope,
+// matching one of the include globs when there are some, and matching none of the exclude globs.
+// A sub-rule is also restricted to the scope of its composite rule.
+func inScope(rule Rule, relPath string) bool {
+	if rule.parent != nil && !inScope(*rule.parent, relPath) {
+		return false
+	}
+	if rule.Scope == ScopeRoot && strings.Contains(relPath, "/") {
+		return false
+	}
+	if len(rule.Include) > 0 {
+		included := false
+		for _, include := range rule.Include {
+			if MatchGlob(include, relPath) {
+				included = true
+				break
+			}
+		}
+		if !included {
+			return false
+		}
+	}
+	for _, exclude := range rule.Exclude {
+		if MatchGlob(exclude, relPath) {
+			return false
+		}
+	}
+	return true
+}
+
+// Evaluate checks every rule against the files of a repository, leaving out the files
+// matching one of the exclude globs. The tracked files are the paths of the analyzed commit,
+// which keep a committed file in the snapshot even when .gitignore matches it, also when the
+// files were written out of the repository. The license is the one already detected for the
+// branch, or nil to detect it on the first license rule. The results are in the same order as the rules.
+func Evaluate(repoPath string, trackedPaths []string, license *structs.LicenseInfo, rules []Rule, excludes []string) ([]structs.RuleResult, error) {
+	s, err := newSnapshot(repoPath, trackedPaths, excludes)
+	if err != nil {
+		return nil, err
+	}
+	s.license = license
+
+	results := make([]structs.RuleResult, 0, len(rules))
+	for _, rule := range rules {
+		passed, paths := s.evaluate(rule)
+		results = append(results, structs.RuleResult{
+			ID:       rule.ID,
+			Title:    rule.Title,
+			Severity: rule.Severity,
+			Passed:   passed,
+			Paths:    paths,
+		})
+	}
+	return results, nil
+}
+
+// evaluate returns whether a rule passes and the paths that explain the result:
+// the files found for file_exists, content_matches and structured, the test
+// files for has_tests, and the offending files for file_absent
+func (s *snapshot) evaluate(rule Rule) (bool, []string) {
+	if len(rule.All) > 0 || len(rule.Any) > 0 {
+		return s.evaluateComposite(rule)
+	}
+
+	switch rule.Type {
+	case TypeFileExists:
+		matches := s.filesNamed(rule, rule.Pattern)
+		return len(matches) > 0, matches
+	case TypeFileAbsent:
+		matches := s.filesNamed(rule, rule.Pattern)
+		return len(matches) == 0, matches
+	case TypeContentMatches:
+		return s.contentMatches(rule)
+	case TypeStructured:
+		return s.structured(rule)
+	case TypeLicense:
+		return s.approvedLicense(rule)
+	case TypeHasTests:
+		return s.testFiles(rule)
+	}
+	return false, nil
+}
+
+// approvedLicense passes when the license of the repository is one of the licenses of the rule
+func (s *snapshot) approvedLicense(rule Rule) (bool, []string) {
+	if s.license == nil {
+		license, err := licenses.Detect(s.root)
+		if err != nil {
+			fmt.Printf("Error detecting license: %v\n", err)
+		}
+		s.license = &license
+	}
+	var paths []string
+	if s.license.File != "" {
+		paths = []string{s.license.File}
+	}
+	return licenses.IsApproved(s.license.ID, rule.Licenses), paths
+}
+
+// testFiles passes when the scope of the rule has at least one test file
+func (s *snapshot) testFiles(rule Rule) (bool, []string) {
+	var matches []string
+	for _, file := range s.files {
+		if inScope(rule, file) && languages.IsTestFile(file) {
+			matches = append(matches, file)
+		}
+	}
+	return len(matches) > 0, matches
+}
+
+// evaluateComposite passes when all the "all" sub-rules pass and,
+// if there are "any" sub-rules, at least one of them passes. The scope, include and exclude
+// of the composite rule restrict the files of its sub-rules, on top of their own.
+func (s *snapshot) evaluateComposite(rule Rule) (bool, []string) {
+	var paths []string
+	for _, sub := range rule.All {
+		sub.parent = &rule
+		passed, subPaths := s.evaluate(sub)
+		paths = append(paths, subPaths...)
+		if !passed {
+			return false, paths
+		}
+	}
+
+	if len(rule.Any) == 0 {
+		return true, paths
+	}
+	for _, sub := range rule.Any {
+		sub.parent = &rule
+		passed, subPaths := s.evaluate(sub)
+		if passed {
+			return true, append(paths, subPaths...)
+		}
+	}
+	return false, paths
+}
+
+// contentMatches passes when the content of a file matches the pattern
+func (s *snapshot) contentMatches(rule Rule) (bool, []string) {
+	regex := s.regex(rule.Pattern)
+	if regex == nil {
+		return false, nil
+	}
+
+	files := s.filesNamed(rule, ".")
+	if rule.Files != "" {
+		files = s.filesNamed(rule, rule.Files)
+	}
+	for _, file := range files {
+		if regex.MatchString(s.content(file)) {
+			return true, []string{file}
+		}
+	}
+	return false, nil
+}
+
+// structured passes when a YAML or JSON file has a value at the path satisfying the condition
+func (s *snapshot) structured(rule Rule) (bool, []string) {
+	for _, file := range s.filesNamed(rule, rule.Files) {
+		var document interface{}
+		if err := yaml.Unmarshal([]byte(s.content(file)), &document); err != nil {
+			continue
+		}
+
+		value, found := lookup(document, rule.Path)
+		if !found {
+			continue
+		}
+		text := fmt.Sprintf("%v", value)
+		if rule.Equals != nil && text != *rule.Equals {
+			continue
+		}
+		if rule.Matches != "" {
+			regex := s.regex(rule.Matches)
+			if regex == nil || !regex.MatchString(text) {
+				continue
+			}
+		}
+		return true, []string{file}
+	}
+	return false, nil
+}
+
+// lookup follows a dot-separated path through maps and lists (numeric segments index lists)
+func lookup(document interface{}, keyPath string) (interface{}, bool) {
+	current := document
+	for _, key := range strings.Split(keyPath, ".") {
+		switch node := current.(type) {
+		case map[string]interface{}:
+			value, ok := node[key]
+			if !ok {
+				return nil, false
+			}
+			current = value
+		case []interface{}:
+			index, err := strconv.Atoi(key)
+			if err != nil || index < 0 || index >= len(node) {
+				return nil, false
+			}
+			current = node[index]
+		default:
+			return nil, false
+		}
+	}
+	return current, true
+}
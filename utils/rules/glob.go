@@ -0,0 +1,59 @@
+package rules
+
+import (
+	"path"
+	"strings"
+)
+
+// MatchGlob reports whether a slash-separated path relative to the repository root matches a glob.
+// "**" matches any number of directories, the other segments use the syntax of path.Match.
+// A glob ending with "/" or "/**" matches everything under a directory.
+func MatchGlob(glob, relPath string) bool {
+	glob = strings.TrimPrefix(glob, "/")
+	if strings.HasSuffix(glob, "/") {
+		glob += "**"
+	}
+	return matchSegments(strings.Split(glob, "/"), strings.Split(relPath, "/"))
+}
+
+// matchSegments matches the segments of a path against the segments of a glob
+func matchSegments(glob, parts []string) bool {
+	for len(glob) > 0 {
+		if glob[0] == "**" {
+			// Collapse consecutive "**" and try every possible number of directories
+			for len(glob) > 0 && glob[0] == "**" {
+				glob = glob[1:]
+			}
+			if len(glob) == 0 {
+				return true
+			}
+			for i := range parts {
+				if matchSegments(glob, parts[i:]) {
+					return true
+				}
+			}
+			return false
+		}
+
+		if len(parts) == 0 {
+			return false
+		}
+		matched, err := path.Match(glob[0], parts[0])
+		if err != nil || !matched {
+			return false
+		}
+		glob = glob[1:]
+		parts = parts[1:]
+	}
+	return len(parts) == 0
+}
+
+// validGlob reports whether every segment of a glob is a valid path.Match pattern
+func validGlob(glob string) bool {
+	for _, segment := range strings.Split(glob, "/") {
+		if _, err := path.Match(segment, ""); err != nil {
+			return false
+		}
+	}
+	return true
+}
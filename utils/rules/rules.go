@@ -0,0 +1,253 @@
+package rules
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"regexp"
+
+	"github.com/s3pweb/gitArchiveS3Report/utils/structs"
+	"gopkg.in/yaml.v3"
+)
+
+// Matcher types of a rule
+const (
+	TypeFileExists     = "file_exists"
+	TypeFileAbsent     = "file_absent"
+	TypeContentMatches = "content_matches"
+	TypeStructured     = "structured"
+	TypeLicense        = "license"
+	TypeHasTests       = "has_tests"
+)
+
+// Scopes of a rule
+const (
+	ScopeAnywhere = "anywhere"
+	ScopeRoot     = "root"
+)
+
+// Groups of the rules created from the flat search lists of the .env file.
+// Rules loaded from the rules file have no group.
+const (
+	GroupFiles     = "files"
+	GroupTerms     = "terms"
+	GroupForbidden = "forbidden"
+)
+
+// Rule is a compliance rule. A rule either has a matcher type or combines
+// sub-rules with "all" (AND) or "any" (OR).
+type Rule struct {
+	ID          string `yaml:"id"`
+	Title       string `yaml:"title"`
+	Description string `yaml:"description"`
+	Severity    string `yaml:"severity"`
+	// Weight is the importance of the rule in the compliance score, 1 by default and 0 to leave it out
+	Weight float64 `yaml:"weight"`
+	Type   string  `yaml:"type"`
+	// Pattern is the file name regex of file_exists and file_absent,
+	// and the content regex of content_matches
+	Pattern string `yaml:"pattern"`
+	// Files restricts content_matches and structured to the files whose name matches this regex
+	Files string `yaml:"files"`
+	// Scope is "root" to only check the files at the root of the repository, or "anywhere" (default)
+	Scope string `yaml:"scope"`
+	// Include and Exclude are globs on the path of the files, relative to the repository root,
+	// e.g. "src/**" or "**/test/**"
+	Include []string `yaml:"include"`
+	Exclude []string `yaml:"exclude"`
+	// Path is the dot-separated key of a structured assertion, e.g. "pipelines.default"
+	Path string `yaml:"path"`
+	// Equals and Matches are the optional conditions of a structured assertion,
+	// which otherwise only checks that the path exists
+	Equals  *string `yaml:"equals"`
+	Matches string  `yaml:"matches"`
+	// Licenses are the approved licenses of a license rule, e.g. [MIT, Apache-2.0]. Without any,
+	// the rule passes for any recognised license.
+	Licenses []string `yaml:"licenses"`
+	All      []Rule   `yaml:"all"`
+	Any      []Rule   `yaml:"any"`
+	Group    string   `yaml:"-"`
+	// parent is the composite rule of a sub-rule, whose scope also applies to the sub-rule
+	parent *Rule
+}
+
+// rulesFile is the format of the rules file
+type rulesFile struct {
+	Rules []Rule `yaml:"rules"`
+}
+
+var severities = map[string]bool{
+	structs.SeverityLow:      true,
+	structs.SeverityMedium:   true,
+	structs.SeverityHigh:     true,
+	structs.SeverityCritical: true,
+}
+
+// reservedColumns are the report columns that are not fields of BranchInfo
+var reservedColumns = map[string]bool{
+	"Count":           true,
+	ApprovedLicenseID: true,
+}
+
+// Load reads and validates a YAML rules file. The legacy rules are the rules of the flat search
+// lists: a rule of the file cannot share the ID of one of them, of a field of BranchInfo or of
+// another report column, since the report would show that column instead of the rule result.
+func Load(path string, legacy []Rule) ([]Rule, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var file rulesFile
+	if err := yaml.Unmarshal(content, &file); err != nil {
+		return nil, fmt.Errorf("failed to parse rules file %s: %w", path, err)
+	}
+	// The keys of each rule tell an absent weight, which defaults to 1, from a weight of 0
+	var keys struct {
+		Rules []map[string]interface{} `yaml:"rules"`
+	}
+	if err := yaml.Unmarshal(content, &keys); err != nil {
+		return nil, fmt.Errorf("failed to parse rules file %s: %w", path, err)
+	}
+
+	legacyIDs := make(map[string]bool)
+	for _, rule := range legacy {
+		legacyIDs[rule.ID] = true
+	}
+	branchInfo := reflect.TypeOf(structs.BranchInfo{})
+
+	ids := make(map[string]bool)
+	for i := range file.Rules {
+		rule := &file.Rules[i]
+		if rule.ID == "" {
+			return nil, fmt.Errorf("rule #%d has no id", i+1)
+		}
+		if ids[rule.ID] {
+			return nil, fmt.Errorf("rule %s is defined twice", rule.ID)
+		}
+		ids[rule.ID] = true
+		if _, isField := branchInfo.FieldByName(rule.ID); isField || reservedColumns[rule.ID] {
+			return nil, fmt.Errorf("rule %s has the id of a report column", rule.ID)
+		}
+		if legacyIDs[rule.ID] {
+			return nil, fmt.Errorf("rule %s has the id of an entry of FILES_TO_SEARCH, TERMS_TO_SEARCH or FORBIDDEN_FILES_TO_SEARCH", rule.ID)
+		}
+
+		if rule.Title == "" {
+			rule.Title = rule.ID
+		}
+		if rule.Severity == "" {
+			rule.Severity = structs.SeverityMedium
+		}
+		if !severities[rule.Severity] {
+			return nil, fmt.Errorf("rule %s has an unknown severity: %s", rule.ID, rule.Severity)
+		}
+		if rule.Weight < 0 {
+			return nil, fmt.Errorf("rule %s has a negative weight: %v", rule.ID, rule.Weight)
+		}
+		if _, hasWeight := keys.Rules[i]["weight"]; !hasWeight {
+			rule.Weight = 1
+		}
+		if err := validate(*rule); err != nil {
+			return nil, fmt.Errorf("rule %s: %w", rule.ID, err)
+		}
+	}
+
+	return file.Rules, nil
+}
+
+// validate checks the matcher of a rule and of its sub-rules
+func validate(rule Rule) error {
+	composite := len(rule.All) > 0 || len(rule.Any) > 0
+	if composite {
+		if rule.Type != "" {
+			return fmt.Errorf("a rule cannot have both a type and all/any sub-rules")
+		}
+		for _, sub := range append(append([]Rule{}, rule.All...), rule.Any...) {
+			if err := validate(sub); err != nil {
+				return err
+			}
+		}
+		return validateScope(rule)
+	}
+
+	switch rule.Type {
+	case TypeFileExists, TypeFileAbsent, TypeContentMatches:
+		if rule.Pattern == "" {
+			return fmt.Errorf("%s requires a pattern", rule.Type)
+		}
+	case TypeStructured:
+		if rule.Files == "" || rule.Path == "" {
+			return fmt.Errorf("%s requires files and path", rule.Type)
+		}
+	case TypeLicense:
+		// Without licenses, any recognised license passes
+	case TypeHasTests:
+		// Test files are recognised by the conventions of each language
+	case "":
+		return fmt.Errorf("a rule requires a type or all/any sub-rules")
+	default:
+		return fmt.Errorf("unknown type: %s", rule.Type)
+	}
+
+	for _, pattern := range []string{rule.Pattern, rule.Files, rule.Matches} {
+		if _, err := regexp.Compile(pattern); err != nil {
+			return fmt.Errorf("invalid regex %q: %w", pattern, err)
+		}
+	}
+
+	return validateScope(rule)
+}
+
+// validateScope checks the scope and the include and exclude globs of a rule
+func validateScope(rule Rule) error {
+	if rule.Scope != "" && rule.Scope != ScopeAnywhere && rule.Scope != ScopeRoot {
+		return fmt.Errorf("unknown scope: %s", rule.Scope)
+	}
+	for _, glob := range append(append([]string{}, rule.Include...), rule.Exclude...) {
+		if !validGlob(glob) {
+			return fmt.Errorf("invalid glob %q", glob)
+		}
+	}
+	return nil
+}
+
+// FromLegacy converts the FILES_TO_SEARCH, TERMS_TO_SEARCH and FORBIDDEN_FILES_TO_SEARCH
+// lists into rules. The ID of each rule is its regex, as used by the report columns.
+func FromLegacy(files, terms, forbidden []string) []Rule {
+	var rules []Rule
+	for _, file := range files {
+		rules = append(rules, Rule{ID: file, Title: file, Severity: structs.SeverityMedium,
+			Type: TypeFileExists, Pattern: file, Weight: 1, Group: GroupFiles})
+	}
+	for _, term := range terms {
+		rules = append(rules, Rule{ID: term, Title: term, Severity: structs.SeverityMedium,
+			Type: TypeContentMatches, Pattern: term, Weight: 1, Group: GroupTerms})
+	}
+	for _, file := range forbidden {
+		rules = append(rules, Rule{ID: file, Title: file, Severity: structs.SeverityHigh,
+			Type: TypeFileAbsent, Pattern: file, Weight: 1, Group: GroupForbidden})
+	}
+	return rules
+}
+
+// ApprovedLicenseID is the ID of the rule created from APPROVED_LICENSES
+const ApprovedLicenseID = "approved-license"
+
+// FromApprovedLicenses converts the APPROVED_LICENSES list into a license rule,
+// reported with the rules of the rules file
+func FromApprovedLicenses(licenses []string) Rule {
+	return Rule{ID: ApprovedLicenseID, Title: "Approved license", Severity: structs.SeverityHigh,
+		Type: TypeLicense, Licenses: licenses, Weight: 1}
+}
+
+// CustomIDs returns the IDs of the rules loaded from the rules file, in order
+func CustomIDs(rules []Rule) []string {
+	var ids []string
+	for _, rule := range rules {
+		if rule.Group == "" {
+			ids = append(ids, rule.ID)
+		}
+	}
+	return ids
+}
@@ -0,0 +1,74 @@
+package rules
+
+import (
+	"math"
+
+	"github.com/s3pweb/gitArchiveS3Report/utils/structs"
+)
+
+// DefaultPenalties are the points removed from the compliance score for each forbidden file found, by severity
+var DefaultPenalties = map[string]float64{
+	structs.SeverityLow:      2,
+	structs.SeverityMedium:   5,
+	structs.SeverityHigh:     10,
+	structs.SeverityCritical: 20,
+}
+
+// grades are the minimum score of each grade, from the best to the worst
+var grades = []struct {
+	Grade string
+	Min   float64
+}{
+	{"A", 90},
+	{"B", 75},
+	{"C", 60},
+	{"D", 45},
+	{"E", 30},
+	{"F", 0},
+}
+
+// Score computes the compliance score of a branch, from 0 to 100, from the results of the rules,
+// which must be in the same order as the rules.
+//
+// The required items (searched files and terms, and the rules of the rules file) give the base score:
+// the weight of the passed items over the total weight. Each forbidden file found, from the forbidden
+// files list or a file_absent rule of the rules file, then removes the penalty of its severity
+// multiplied by its weight. A branch without required items starts at 100.
+func Score(rules []Rule, results []structs.RuleResult, penalties map[string]float64) float64 {
+	var passedWeight, totalWeight, penalty float64
+	for i, result := range results {
+		if i >= len(rules) {
+			break
+		}
+		rule := rules[i]
+
+		if rule.Group == GroupForbidden || rule.Type == TypeFileAbsent {
+			if !result.Passed {
+				penalty += penalties[rule.Severity] * rule.Weight
+			}
+			continue
+		}
+
+		totalWeight += rule.Weight
+		if result.Passed {
+			passedWeight += rule.Weight
+		}
+	}
+
+	score := 100.0
+	if totalWeight > 0 {
+		score = passedWeight / totalWeight * 100
+	}
+	score = math.Max(0, score-penalty)
+	return math.Round(score*10) / 10
+}
+
+// Grade converts a compliance score into a grade from A (90 and above) to F (below 30)
+func Grade(score float64) string {
+	for _, grade := range grades {
+		if score >= grade.Min {
+			return grade.Grade
+		}
+	}
+	return grades[len(grades)-1].Grade
+}
@@ -2,6 +2,14 @@ package structs
 
 import "time"
 
+// Severity levels of the findings reported for a branch
+const (
+	SeverityLow      = "low"
+	SeverityMedium   = "medium"
+	SeverityHigh     = "high"
+	SeverityCritical = "critical"
+)
+
 // BranchInfo represents information about a branch
 type BranchInfo struct {
 	RepoName                string
@@ -22,4 +30,352 @@ type BranchInfo struct {
 	ForbiddenCount          string
 	IsShallow               bool
 	CloneDepth              int
+	MainLanguage            string
+	TotalLOC                int
+	Languages               map[string]LanguageStats
+	ComposeFiles            string
+	ServiceCount            int
+	Images                  string
+	PublishedPorts          string
+	Networks                string
+	TraefikRules            string
+	Services                []ServiceInfo
+	Dockerfiles             string
+	BaseImages              string
+	DockerFindingsCount     int
+	DockerFindings          []DockerFinding
+	CIPipelines             string
+	CIImages                string
+	CIHasTests              bool
+	CIHasLint               bool
+	CIHasSonar              bool
+	CIDeployments           string
+	CIPipes                 string
+	CIGaps                  string
+	CI                      PipelineInfo
+	RulesCount              string
+	ComplianceScore         float64
+	ComplianceGrade         string
+	RuleResults             map[string]RuleResult
+	RulePaths               map[string][]string
+	DefaultBranch           string
+	IsDefault               bool
+	BranchRole              string
+	IsMerged                bool
+	Ahead                   int
+	Behind                  int
+	BranchStatus            string
+	LatestTag               string
+	LatestTagSemver         bool
+	TagCount                int
+	LastReleaseDate         time.Time
+	DaysSinceRelease        int
+	CommitsSinceTag         int
+	DevelopAhead            int
+	DevelopBehind           int
+	DevelopDiverged         bool
+	MonthlyCommits          []MonthlyCount
+	ActivityTrend           string
+	BusFactor               int
+	BusFactorAuthors        string
+	FormerEmployeesShare    float64
+	FormerEmployees         string
+	Knowledge               KnowledgeInfo
+	LinesAdded              int
+	LinesRemoved            int
+	Churn                   int
+	ChurnByAuthor           map[string]Churn
+	ConventionalShare       float64
+	IssueKeyShare           float64
+	MessagesByAuthor        map[string]MessageStats
+	SignedShare             float64
+	VerifiedShare           float64
+	UnsignedShare           float64
+	TipSigned               bool
+	TipVerified             bool
+	SignaturesByAuthor      map[string]SignatureStats
+	RepoSizeMB              float64
+	PackSizeMB              float64
+	ObjectCount             int
+	FilesOverLFS            int
+	LargestFile             string
+	Size                    SizeInfo
+	License                 string
+	LicenseConfidence       float64
+	LicenseFile             string
+	HasReadme               bool
+	ReadmeWords             int
+	MissingSections         string
+	HasDocsFolder           bool
+	HasChangelog            bool
+	DocScore                float64
+	Docs                    DocsInfo
+	HasCodeOwners           bool
+	CodeOwnersValid         bool
+	CodeOwners              string
+	OwnershipCoverage       float64
+	Ownership               CodeOwnersInfo
+	HasTests                bool
+	TestFiles               int
+	TestLOC                 int
+	TestRatio               float64
+}
+
+// LanguageStats represents the line counts of one language
+type LanguageStats struct {
+	Files   int
+	Code    int
+	Comment int
+	Blank   int
+	// TestFiles and TestCode are the test files and their code lines, included in Files and Code
+	TestFiles int
+	TestCode  int
+}
+
+// Add returns the sum of two language statistics
+func (s LanguageStats) Add(other LanguageStats) LanguageStats {
+	return LanguageStats{
+		Files:     s.Files + other.Files,
+		Code:      s.Code + other.Code,
+		Comment:   s.Comment + other.Comment,
+		Blank:     s.Blank + other.Blank,
+		TestFiles: s.TestFiles + other.TestFiles,
+		TestCode:  s.TestCode + other.TestCode,
+	}
+}
+
+// ServiceInfo represents a service declared in a docker-compose file
+type ServiceInfo struct {
+	File     string
+	Name     string
+	Image    string
+	Tag      string
+	Ports    []PortMapping
+	Networks []string
+	Rules    []string
+	Hosts    []string
+}
+
+// PortMapping represents a port mapping of a docker-compose service
+type PortMapping struct {
+	HostIP    string
+	Published string
+	Target    string
+	Protocol  string
+}
+
+// String formats the port mapping with the docker-compose short syntax
+func (p PortMapping) String() string {
+	mapping := p.Target
+	if p.Published != "" {
+		mapping = p.Published + ":" + mapping
+	}
+	if p.HostIP != "" {
+		mapping = p.HostIP + ":" + mapping
+	}
+	if p.Protocol != "" && p.Protocol != "tcp" {
+		mapping += "/" + p.Protocol
+	}
+	return mapping
+}
+
+// DockerFinding represents a problem found by the lint rules of a Dockerfile
+type DockerFinding struct {
+	File     string
+	Line     int
+	Rule     string
+	Severity string
+	Message  string
+}
+
+// PipelineInfo represents the analysis of a bitbucket-pipelines.yml file
+type PipelineInfo struct {
+	Present     bool
+	ParseError  string
+	Pipelines   []string
+	Images      []string
+	HasTests    bool
+	HasLint     bool
+	HasSonar    bool
+	Deployments []string
+	Pipes       []string
+	Gaps        []string
+}
+
+// Branch statuses, from the age of the last commit
+const (
+	BranchStatusActive    = "active"
+	BranchStatusStale     = "stale"
+	BranchStatusAbandoned = "abandoned"
+)
+
+// Activity trends, from the commits of the last months
+const (
+	TrendRising    = "rising"
+	TrendStable    = "stable"
+	TrendDeclining = "declining"
+	TrendInactive  = "inactive"
+)
+
+// Churn represents the lines added and removed by the commits of an author or a branch
+type Churn struct {
+	Commits int
+	Added   int
+	Removed int
+}
+
+// MessageStats counts the commits of an author or a branch whose message follows Conventional Commits
+// and those referencing an issue key. Example is the subject of the most recent commit missing either.
+type MessageStats struct {
+	Commits      int
+	Conventional int
+	IssueKey     int
+	Example      string
+}
+
+// SignatureStats counts the commits of an author or a branch that are signed, with GPG, SSH or X.509,
+// and those whose GPG signature is verified against the trusted keyring
+type SignatureStats struct {
+	Commits  int
+	Signed   int
+	Verified int
+}
+
+// MonthlyCount represents the number of commits of a month ("2006-01")
+type MonthlyCount struct {
+	Month   string
+	Commits int
+}
+
+// RuleResult represents the result of a compliance rule on a branch
+type RuleResult struct {
+	ID       string
+	Title    string
+	Severity string
+	Passed   bool
+	Paths    []string
+}
+
+// ReleaseInfo represents the tags of a repository and the work not released yet
+type ReleaseInfo struct {
+	LatestTag       string
+	LatestTagSemver bool
+	TagCount        int
+	LastReleaseDate time.Time
+	// DaysSinceRelease is -1 when the repository has no tag
+	DaysSinceRelease int
+	// CommitsSinceTag is the number of commits of the default branch that are not in the latest tag
+	CommitsSinceTag int
+	// DevelopAhead and DevelopBehind compare the develop branch with the default branch
+	DevelopAhead    int
+	DevelopBehind   int
+	DevelopDiverged bool
+}
+
+// KnowledgeInfo represents how the knowledge of a repository is spread among its authors
+type KnowledgeInfo struct {
+	// Basis is "commits" or "lines" (surviving lines of the last commit)
+	Basis string
+	// BusFactor is the minimum number of authors holding the configured share of the commits or lines
+	BusFactor  int
+	KeyAuthors []string
+	// FormerShare is the percentage of the commits or lines held by former employees
+	FormerShare   float64
+	FormerAuthors []string
+}
+
+// SizeInfo represents the size of a repository and its largest files, in the tree of the default
+// branch and anywhere in its history
+type SizeInfo struct {
+	// DiskSizeMB is the size of the loose and packed objects, PackSizeMB the size of the pack files alone
+	DiskSizeMB  float64
+	PackSizeMB  float64
+	ObjectCount int
+	// FilesOverLFS is the number of files of the default branch larger than LFS_THRESHOLD_MB
+	FilesOverLFS int
+	LargeFiles   []LargeFile
+}
+
+// LargeFile represents one of the largest files of a repository
+type LargeFile struct {
+	Path   string
+	SizeMB float64
+	// Commit and Date identify the commit that introduced the file, when it is known
+	Commit string
+	Date   time.Time
+	// InTree tells whether the file is in the tree of the default branch
+	InTree bool
+	// OverLFS tells whether the file is larger than LFS_THRESHOLD_MB and should be tracked with Git LFS
+	OverLFS bool
+}
+
+// Sources of a detected license
+const (
+	LicenseSourceFile    = "file"
+	LicenseSourceHeaders = "headers"
+)
+
+// LicenseInfo represents the license detected in a repository
+type LicenseInfo struct {
+	// ID is an SPDX identifier such as MIT or Apache-2.0, "proprietary", "unknown",
+	// or empty when the repository has no license
+	ID string
+	// Confidence is the percentage of the phrases of the license found in its text,
+	// or of the SPDX headers agreeing with it. For an unknown license, it is the
+	// confidence of the closest license.
+	Confidence float64
+	// File is the license file, empty when the license comes from the SPDX headers
+	File   string
+	Source string
+}
+
+// DocsInfo represents the documentation of a repository
+type DocsInfo struct {
+	HasReadme  bool
+	ReadmeFile string
+	// Words is the word count of the README, outside of its code blocks
+	Words int
+	// Sections are the required sections found among the headings of the README,
+	// and MissingSections those that are not
+	Sections        []string
+	MissingSections []string
+	HasDocsFolder   bool
+	HasChangelog    bool
+	// Score weighs the checks above out of 100, rounded
+	Score float64
+}
+
+// CodeOwnersInfo represents the CODEOWNERS file of a repository and the files it covers
+type CodeOwnersInfo struct {
+	// File is the path of the CODEOWNERS file, empty when the repository has none
+	File    string
+	Present bool
+	// Valid tells whether every line of the file could be parsed, and ParseErrors describes those that could not
+	Valid       bool
+	ParseErrors []string
+	Rules       int
+	// Owners are the owners of the rules, those covering the most files first
+	Owners []string
+	// Files is the number of tracked files, and Covered those owned by at least one owner
+	Files    int
+	Covered  int
+	Coverage float64
+}
+
+// Stages at which a repository of the workspace can be left out of the report
+const (
+	ProblemStageFiltered  = "filtered"
+	ProblemStageEmpty     = "empty"
+	ProblemStageOpen      = "open"
+	ProblemStageCheckout  = "checkout"
+	ProblemStageAnalysis  = "analysis"
+	ProblemStageTimeout   = "timeout"
+	ProblemStageCancelled = "cancelled"
+)
+
+// RepoProblem represents a folder of the workspace that is missing from the report, or only partly analyzed
+type RepoProblem struct {
+	RepoName string
+	Stage    string
+	Error    string
 }